@@ -12,9 +12,10 @@ go build -o bin/juggler cmd/app/main.go
 
 if [ $? -eq 0 ]; then
     echo "✅ Build successful!"
-    echo "Run the application with: ./bin/juggler [port]"
+    echo "Run the application with: ./bin/juggler [command] [options]"
     echo "Example: ./bin/juggler (uses default port 8080)"
-    echo "Example: ./bin/juggler 9000 (uses custom port 9000)"
+    echo "Example: ./bin/juggler serve --port 9000 (uses custom port 9000)"
+    echo "Example: ./bin/juggler run --balls 5 --minutes 2 (headless run)"
     echo ""
     echo "Configure balls and time through the web interface"
     echo "Web interface will be available at: http://localhost:8080"
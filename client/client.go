@@ -0,0 +1,917 @@
+// Package client is a typed Go client for the Juggler HTTP API described
+// by /api/v1/openapi.json. Its types mirror the schemas of that document and
+// are checked against it by the tests in the test directory.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Ball mirrors the Ball schema
+type Ball struct {
+	ID         int       `json:"id"`
+	Status     string    `json:"status"`
+	FlightTime int       `json:"flight_time"`
+	Elapsed    int       `json:"elapsed"`
+	StartTime  time.Time `json:"start_time"`
+	Hand       string    `json:"hand"`
+	Removing   bool      `json:"removing,omitempty"`
+	Performer  int       `json:"performer,omitempty"`
+	PassFrom   int       `json:"pass_from,omitempty"`
+	BallDef
+}
+
+// BallDef mirrors the BallDef schema
+type BallDef struct {
+	Name   string `json:"name,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Size   int    `json:"size,omitempty"`
+	Weight int    `json:"weight,omitempty"`
+	Type   string `json:"type,omitempty"` // "ball" (default), "club" or "ring"
+}
+
+// StatsResponse mirrors the StatsResponse schema
+type StatsResponse struct {
+	InHand      int    `json:"in_hand"`
+	InAir       int    `json:"in_air"`
+	Balls       []Ball `json:"balls"`
+	TimeElapsed int    `json:"time_elapsed"`
+	IsFinished  bool   `json:"is_finished"`
+	IsRunning   bool   `json:"is_running"`
+	TotalBalls  int    `json:"total_balls"`
+	TotalTime   int    `json:"total_time"`
+	Manual      bool   `json:"manual"`
+	Score       Score  `json:"score"`
+
+	Performer *PerformerState `json:"performer,omitempty"`
+
+	Passing string         `json:"passing,omitempty"`
+	Troupe  []TroupeMember `json:"troupe,omitempty"`
+
+	Peer *PeerStatus `json:"peer,omitempty"`
+}
+
+// PeerStatus mirrors the PeerStatus schema
+type PeerStatus struct {
+	Name        string  `json:"name"`
+	Connected   bool    `json:"connected"`
+	Peer        string  `json:"peer,omitempty"`
+	Address     string  `json:"address,omitempty"`
+	ClockOffset float64 `json:"clock_offset_ms"`
+	RTT         float64 `json:"rtt_ms"`
+	InTransit   int     `json:"in_transit"`
+	Passed      int     `json:"passed"`
+	Received    int     `json:"received"`
+	Lost        int     `json:"lost"`
+}
+
+// TroupeMember mirrors the TroupeMember schema
+type TroupeMember struct {
+	Performer      int `json:"performer"`
+	InHand         int `json:"in_hand"`
+	Incoming       int `json:"incoming"`
+	Throws         int `json:"throws"`
+	PassesMade     int `json:"passes_made"`
+	PassesReceived int `json:"passes_received"`
+	Catches        int `json:"catches"`
+	Drops          int `json:"drops"`
+
+	State *PerformerState `json:"state,omitempty"`
+}
+
+// Score mirrors the Score schema
+type Score struct {
+	Points           int           `json:"points"`
+	Streak           int           `json:"streak"`
+	LongestStreak    int           `json:"longest_streak"`
+	Catches          int           `json:"catches"`
+	Drops            int           `json:"drops"`
+	CatchesPerMinute float64       `json:"catches_per_minute"`
+	Achievements     []Achievement `json:"achievements"`
+}
+
+// Achievement mirrors the Achievement schema
+type Achievement struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// StartRequest mirrors the StartRequest schema
+type StartRequest struct {
+	TotalBalls  int       `json:"total_balls"`
+	TimeMinutes int       `json:"time_minutes"`
+	Mode        string    `json:"mode,omitempty"` // "reject" (default), "restart" or "queue"
+	Manual      bool      `json:"manual,omitempty"`
+	Player      string    `json:"player,omitempty"`
+	Balls       []BallDef `json:"balls,omitempty"`
+
+	Performer PerformerOptions `json:"performer,omitzero"`
+	Passing   string           `json:"passing,omitempty"`
+}
+
+// PerformerOptions mirrors the PerformerOptions schema
+type PerformerOptions struct {
+	Skill    float64 `json:"skill"`
+	Fatigue  float64 `json:"fatigue"`
+	Recovery float64 `json:"recovery"`
+}
+
+// PerformerState mirrors the PerformerState schema
+type PerformerState struct {
+	Skill      float64 `json:"skill"`
+	Stamina    float64 `json:"stamina"`
+	Form       float64 `json:"form"`
+	MissChance float64 `json:"miss_chance"`
+}
+
+// SessionInfo mirrors the SessionInfo schema
+type SessionInfo struct {
+	ID          int       `json:"id"`
+	Player      string    `json:"player,omitempty"`
+	TotalBalls  int       `json:"total_balls"`
+	TimeMinutes int       `json:"time_minutes"`
+	StartTime   time.Time `json:"start_time"`
+}
+
+// StartResponse mirrors the StartResponse schema
+type StartResponse struct {
+	Status        string       `json:"status"`
+	Message       string       `json:"message"`
+	Session       *SessionInfo `json:"session,omitempty"`
+	QueuePosition int          `json:"queue_position,omitempty"`
+}
+
+// StatusResponse mirrors the StatusResponse schema
+type StatusResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// FieldError mirrors the FieldError schema
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// BallResponse mirrors the BallResponse schema
+type BallResponse struct {
+	ID         int    `json:"id"`
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+	TotalBalls int    `json:"total_balls"`
+}
+
+// ThrowRequest mirrors the ThrowRequest schema
+type ThrowRequest struct {
+	BallID int `json:"ball_id,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// ThrowResponse mirrors the ThrowResponse schema
+type ThrowResponse struct {
+	BallID     int    `json:"ball_id"`
+	Hand       string `json:"hand"`
+	FlightTime int    `json:"flight_time"`
+	Message    string `json:"message"`
+}
+
+// Player mirrors the Player schema
+type Player struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LeaderboardEntry mirrors the LeaderboardEntry schema
+type LeaderboardEntry struct {
+	Rank             int       `json:"rank"`
+	Value            float64   `json:"value"`
+	Player           string    `json:"player"`
+	Session          int       `json:"session"`
+	Balls            int       `json:"balls"`
+	Manual           bool      `json:"manual"`
+	StartTime        time.Time `json:"start_time"`
+	Seconds          int       `json:"seconds"`
+	Points           int       `json:"points"`
+	LongestStreak    int       `json:"longest_streak"`
+	Catches          int       `json:"catches"`
+	Drops            int       `json:"drops"`
+	CatchesPerMinute float64   `json:"catches_per_minute"`
+}
+
+// LeaderboardResponse mirrors the LeaderboardResponse schema
+type LeaderboardResponse struct {
+	Metric  string             `json:"metric"`
+	Balls   int                `json:"balls,omitempty"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// Event mirrors the Event schema
+type Event struct {
+	Time       time.Time `json:"time"`
+	BallID     int       `json:"ball_id"`
+	Type       string    `json:"type"` // "throw", "catch" or "drop"
+	Hand       string    `json:"hand,omitempty"`
+	FlightTime int       `json:"flight_time,omitempty"`
+	Performer  int       `json:"performer,omitempty"`
+	To         int       `json:"to,omitempty"`
+	Remote     bool      `json:"remote,omitempty"`
+}
+
+// ExportEvent mirrors the ExportEvent schema
+type ExportEvent struct {
+	Event
+	Offset float64 `json:"offset"` // seconds since the session started
+}
+
+// BallSummary mirrors the BallSummary schema
+type BallSummary struct {
+	BallID  int           `json:"ball_id"`
+	Throws  int           `json:"throws"`
+	Catches int           `json:"catches"`
+	Drops   int           `json:"drops"`
+	AirTime time.Duration `json:"air_time"`
+}
+
+// ExportResponse mirrors the ExportResponse schema
+type ExportResponse struct {
+	StartTime  time.Time     `json:"start_time"`
+	TotalBalls int           `json:"total_balls"`
+	TotalTime  int           `json:"total_time"`
+	IsRunning  bool          `json:"is_running"`
+	Events     []ExportEvent `json:"events"`
+	Balls      []BallSummary `json:"balls"`
+}
+
+// EventsResponse mirrors the EventsResponse schema
+type EventsResponse struct {
+	Session int     `json:"session"`
+	Next    int     `json:"next"` // since for the following page
+	Events  []Event `json:"events"`
+}
+
+// EventMessage mirrors the EventMessage schema
+type EventMessage struct {
+	Event
+	Session int `json:"session"`
+	Index   int `json:"index"`
+}
+
+// FlightRange mirrors the FlightRange schema
+type FlightRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// ExperimentRequest mirrors the ExperimentRequest schema
+type ExperimentRequest struct {
+	Balls            []int         `json:"balls"`
+	FlightRanges     []FlightRange `json:"flight_ranges"`
+	ThrowIntervalsMS []int         `json:"throw_intervals_ms"`
+	DropChances      []float64     `json:"drop_chances"`
+	Minutes          int           `json:"minutes"`
+	Runs             int           `json:"runs"`
+	Seed             int64         `json:"seed"`
+}
+
+// ExperimentRow mirrors the ExperimentRow schema
+type ExperimentRow struct {
+	Balls         int             `json:"balls"`
+	FlightRange   FlightRange     `json:"flight_range"`
+	ThrowInterval time.Duration   `json:"throw_interval"`
+	DropChance    float64         `json:"drop_chance"`
+	DropRate      float64         `json:"drop_rate"`
+	AvgInAir      float64         `json:"avg_in_air"`
+	IdleHandTime  float64         `json:"idle_hand_time"`
+	Report        json.RawMessage `json:"report"`
+}
+
+// ExperimentResponse mirrors the ExperimentResponse schema
+type ExperimentResponse struct {
+	ID             string            `json:"id"`
+	Configurations int               `json:"configurations"`
+	Elapsed        int64             `json:"elapsed_ms"`
+	Rows           []ExperimentRow   `json:"rows"`
+	Downloads      map[string]string `json:"downloads"`
+}
+
+// EngineOptions mirrors the EngineOptions schema
+type EngineOptions struct {
+	MinFlightTime   int     `json:"min_flight_time"`
+	MaxFlightTime   int     `json:"max_flight_time"`
+	ThrowIntervalMS int     `json:"throw_interval_ms"`
+	DropChance      float64 `json:"drop_chance"`
+	HandCapacity    int     `json:"hand_capacity"`
+	HoldTimeMS      int     `json:"hold_time_ms"`
+}
+
+// AuthStatus mirrors the AuthStatus schema
+type AuthStatus struct {
+	Enabled   bool   `json:"enabled"`
+	Name      string `json:"name,omitempty"`
+	Role      string `json:"role,omitempty"` // "viewer", "operator" or "admin"
+	CSRFToken string `json:"csrf_token,omitempty"`
+}
+
+// APIError is returned when the server answers with an error status. Code
+// and Details are filled from the error envelope when the server sends one.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    []FieldError
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("juggler API error %d: %s", e.StatusCode, e.Message)
+}
+
+// sessionCookie and csrfHeader carry a login session, see Login
+const (
+	sessionCookie = "juggler_session"
+	csrfHeader    = "X-CSRF-Token"
+)
+
+// Client calls the Juggler HTTP API
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	token      string
+
+	mu      sync.Mutex
+	session string // login session cookie, if logged in
+	csrf    string // CSRF token of the login session
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithRetries sets how many times idempotent requests are retried after
+// network errors, 429 and 5xx responses, and the initial backoff between
+// attempts, which doubles after each retry
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithToken authenticates every request with an API token from the
+// server's auth file
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// New creates a client for the server at baseURL, e.g. "http://localhost:8080"
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		maxRetries: 3,
+		backoff:    100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Stats returns the current juggling statistics
+func (c *Client) Stats(ctx context.Context) (*StatsResponse, error) {
+	var resp StatsResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/stats", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Start starts juggling. Every call sends a fresh Idempotency-Key, so
+// retries after network errors never start a second session.
+func (c *Client) Start(ctx context.Context, req StartRequest) (*StartResponse, error) {
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{"Idempotency-Key": {key}}
+
+	var resp StartResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/start", header, req, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Stop stops juggling
+func (c *Client) Stop(ctx context.Context) (*StatusResponse, error) {
+	var resp StatusResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/stop", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AddBall adds a ball to the session. It is not retried, since a repeated
+// request would add a second ball.
+func (c *Client) AddBall(ctx context.Context) (*BallResponse, error) {
+	var resp BallResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/balls", nil, nil, &resp, false); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveBall removes the ball with the given ID, or marks it for removal
+// when it is in flight
+func (c *Client) RemoveBall(ctx context.Context, id int) (*BallResponse, error) {
+	var resp BallResponse
+	if err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/balls/%d", id), nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Throw throws a ball in manual mode. It is not retried, since a repeated
+// request would throw a second ball.
+func (c *Client) Throw(ctx context.Context, req ThrowRequest) (*ThrowResponse, error) {
+	var resp ThrowResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/throw", nil, req, &resp, false); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Players lists the player profiles
+func (c *Client) Players(ctx context.Context) ([]Player, error) {
+	var resp []Player
+	if err := c.do(ctx, http.MethodGet, "/api/v1/players", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddPlayer creates a player profile. A retry after a lost response
+// answers 409, since the profile already exists.
+func (c *Client) AddPlayer(ctx context.Context, name string) (*Player, error) {
+	var resp Player
+	body := struct {
+		Name string `json:"name"`
+	}{name}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/players", nil, body, &resp, false); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Leaderboard returns up to limit runs ranked by metric, only those with
+// the given number of balls unless balls is 0. Zero values use the server defaults.
+func (c *Client) Leaderboard(ctx context.Context, metric string, balls, limit int) (*LeaderboardResponse, error) {
+	query := url.Values{}
+	if metric != "" {
+		query.Set("metric", metric)
+	}
+	if balls > 0 {
+		query.Set("balls", strconv.Itoa(balls))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var resp LeaderboardResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/leaderboard?"+query.Encode(), nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Options returns the engine options
+func (c *Client) Options(ctx context.Context) (*EngineOptions, error) {
+	var resp EngineOptions
+	if err := c.do(ctx, http.MethodGet, "/api/v1/options", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetOptions replaces the engine options and returns those now in use.
+// It needs the admin role.
+func (c *Client) SetOptions(ctx context.Context, opts EngineOptions) (*EngineOptions, error) {
+	var resp EngineOptions
+	if err := c.do(ctx, http.MethodPut, "/api/v1/options", nil, opts, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Export returns the timeline of the current or most recent session
+func (c *Client) Export(ctx context.Context) (*ExportResponse, error) {
+	var resp ExportResponse
+	if err := c.do(ctx, http.MethodGet, "/api/v1/export?format=json", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExportTable returns one table of the timeline, "events" or "balls", as
+// "csv" or "jsonl"
+func (c *Client) ExportTable(ctx context.Context, format, table string) ([]byte, error) {
+	query := url.Values{"format": {format}, "table": {table}}
+	var data []byte
+	if err := c.do(ctx, http.MethodGet, "/api/v1/export?"+query.Encode(), nil, nil, &data, true); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Events returns the current session's events from index since on
+func (c *Client) Events(ctx context.Context, since int) (*EventsResponse, error) {
+	var resp EventsResponse
+	path := "/api/v1/events?since=" + strconv.Itoa(since)
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// WatchEvents follows the events of the current session from index since
+// on, and of every session started later, until ctx is cancelled. A
+// negative since follows new events only.
+func (c *Client) WatchEvents(ctx context.Context, since int) (*EventStream, error) {
+	path := "/api/v1/events"
+	if since >= 0 {
+		path += "?since=" + strconv.Itoa(since)
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// The stream lasts longer than the timeout of ordinary requests
+	hc := *c.httpClient
+	hc.Timeout = 0
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return nil, decodeError(resp.StatusCode, data)
+	}
+	return &EventStream{body: resp.Body, scanner: bufio.NewScanner(resp.Body)}, nil
+}
+
+// EventStream is a stream of server-sent events opened by WatchEvents
+type EventStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// Recv waits for the next event. It returns io.EOF when the server ends
+// the stream.
+func (s *EventStream) Recv() (*EventMessage, error) {
+	var data []byte
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		if line == "" {
+			if len(data) == 0 {
+				continue
+			}
+			var msg EventMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				return nil, fmt.Errorf("decoding event: %v", err)
+			}
+			return &msg, nil
+		}
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimPrefix(value, " ")...)
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Close ends the stream
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
+
+// RunExperiment runs a parameter sweep on the server. It is not retried,
+// since a repeated request would run the sweep again.
+func (c *Client) RunExperiment(ctx context.Context, req ExperimentRequest) (*ExperimentResponse, error) {
+	var resp ExperimentResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/experiments", nil, req, &resp, false); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Experiment downloads the results of an experiment as "json", "csv" or "text"
+func (c *Client) Experiment(ctx context.Context, id, format string) ([]byte, error) {
+	path := "/api/v1/experiments/" + url.PathEscape(id) + "?format=" + url.QueryEscape(format)
+	var data []byte
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &data, true); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ExperimentChart returns an SVG chart of one metric of an experiment:
+// "drop_rate", "avg_in_air" or "idle_hand_time"
+func (c *Client) ExperimentChart(ctx context.Context, id, metric string) ([]byte, error) {
+	path := "/api/v1/experiments/" + url.PathEscape(id) + "/charts/" + url.PathEscape(metric) + ".svg"
+	var data []byte
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &data, true); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Login logs in with a username and password. Later requests use the
+// login session instead of the token, sending its CSRF token with
+// those that change state, until Logout.
+func (c *Client) Login(ctx context.Context, name, password string) (*AuthStatus, error) {
+	var resp AuthStatus
+	body := struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}{name, password}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/auth/login", nil, body, &resp, false); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.csrf = resp.CSRFToken
+	c.mu.Unlock()
+	return &resp, nil
+}
+
+// Logout ends the login session
+func (c *Client) Logout(ctx context.Context) (*StatusResponse, error) {
+	var resp StatusResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/auth/logout", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.session, c.csrf = "", ""
+	c.mu.Unlock()
+	return &resp, nil
+}
+
+// AuthStatus tells whether the server requires authentication and who
+// the client is to it
+func (c *Client) AuthStatus(ctx context.Context) (*AuthStatus, error) {
+	var resp AuthStatus
+	if err := c.do(ctx, http.MethodGet, "/api/v1/auth/status", nil, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// OpenAPI returns the OpenAPI document describing the API
+func (c *Client) OpenAPI(ctx context.Context) (json.RawMessage, error) {
+	var data []byte
+	if err := c.do(ctx, http.MethodGet, "/api/v1/openapi.json", nil, nil, &data, true); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// do sends a request, retrying if allowed, and decodes the JSON response
+// into out, or stores the raw body if out is a *[]byte
+func (c *Client) do(ctx context.Context, method, path string, header http.Header, body, out interface{}, retry bool) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	attempts := 1
+	if retry {
+		attempts += c.maxRetries
+	}
+	backoff := c.backoff
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			wait := backoff
+			if apiErr, ok := lastErr.(*retryAfterError); ok && apiErr.after > 0 {
+				wait = apiErr.after
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			backoff *= 2
+		}
+
+		err := c.send(ctx, method, path, header, payload, out)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retryable(err) {
+			return unwrap(err)
+		}
+		lastErr = err
+	}
+
+	return unwrap(lastErr)
+}
+
+// newRequest builds a request carrying the client's credentials
+func (c *Client) newRequest(ctx context.Context, method, path string, header http.Header, payload []byte) (*http.Request, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	c.mu.Lock()
+	session, csrf := c.session, c.csrf
+	c.mu.Unlock()
+	switch {
+	case session != "":
+		req.AddCookie(&http.Cookie{Name: sessionCookie, Value: session})
+		if method != http.MethodGet && method != http.MethodHead {
+			req.Header.Set(csrfHeader, csrf)
+		}
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// send performs a single HTTP request
+func (c *Client) send(ctx context.Context, method, path string, header http.Header, payload []byte, out interface{}) error {
+	req, err := c.newRequest(ctx, method, path, header, payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &networkError{err}
+	}
+	defer resp.Body.Close()
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == sessionCookie {
+			c.mu.Lock()
+			c.session = cookie.Value
+			c.mu.Unlock()
+		}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &networkError{err}
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := decodeError(resp.StatusCode, data)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return &retryAfterError{APIError: apiErr, after: retryAfter(resp.Header.Get("Retry-After"))}
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	if raw, ok := out.(*[]byte); ok {
+		*raw = data
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decoding response: %v", err)
+	}
+	return nil
+}
+
+// networkError wraps transport failures, which are safe to retry
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string { return e.err.Error() }
+
+// retryAfterError wraps API errors that are safe to retry
+type retryAfterError struct {
+	*APIError
+	after time.Duration
+}
+
+// retryable reports whether err may succeed on another attempt
+func retryable(err error) bool {
+	switch err.(type) {
+	case *networkError, *retryAfterError:
+		return true
+	}
+	return false
+}
+
+// unwrap strips the internal retry markers from err
+func unwrap(err error) error {
+	switch e := err.(type) {
+	case *networkError:
+		return e.err
+	case *retryAfterError:
+		return e.APIError
+	}
+	return err
+}
+
+// decodeError builds an APIError from an error body, which is either the
+// JSON error envelope, JSON with a "message" field or plain text
+func decodeError(status int, data []byte) *APIError {
+	apiErr := &APIError{StatusCode: status}
+
+	var envelope struct {
+		Message string `json:"message"`
+		Error   struct {
+			Code    string       `json:"code"`
+			Message string       `json:"message"`
+			Details []FieldError `json:"details"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &envelope) == nil {
+		if envelope.Error.Message != "" {
+			apiErr.Code = envelope.Error.Code
+			apiErr.Message = envelope.Error.Message
+			apiErr.Details = envelope.Error.Details
+			return apiErr
+		}
+		if envelope.Message != "" {
+			apiErr.Message = envelope.Message
+			return apiErr
+		}
+	}
+	apiErr.Message = strings.TrimSpace(string(data))
+	return apiErr
+}
+
+// newIdempotencyKey returns a random key identifying one logical request
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// retryAfter parses a Retry-After header given in seconds
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
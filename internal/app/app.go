@@ -1,18 +1,37 @@
 package app
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"juggler/internal/auth"
 	"juggler/internal/config"
+	"juggler/internal/grpcapi"
+	"juggler/internal/i18n"
 	"juggler/internal/juggler"
+	"juggler/internal/leaderboard"
+	"juggler/internal/peer"
 	"juggler/internal/web"
 )
 
+// peerRetry is how long to wait before reconnecting to a peer
+const peerRetry = 2 * time.Second
+
 // App represents the main application
 type App struct {
 	juggler   *juggler.Juggler
 	webServer *web.Server
 	config    *config.Config
+	auth      *auth.Authenticator
+	tls       *tls.Config
 }
 
 // NewApp creates a new application
@@ -21,6 +40,15 @@ func NewApp(cfg *config.Config) *App {
 	j := juggler.NewJuggler(0, 0) // Initialize with empty configuration
 	webServer := web.NewServer(j, cfg.WebPort)
 
+	limits := web.DefaultLimits()
+	limits.RequestsPerSecond = cfg.RateLimit
+	limits.Burst = cfg.RateBurst
+	limits.MaxBodyBytes = cfg.MaxBodyBytes
+	webServer.SetLimits(limits)
+	if len(cfg.CORSOrigins) > 0 {
+		webServer.SetCORS(web.DefaultCORSOptions(cfg.CORSOrigins...))
+	}
+
 	return &App{
 		juggler:   j,
 		webServer: webServer,
@@ -28,11 +56,173 @@ func NewApp(cfg *config.Config) *App {
 	}
 }
 
+// connectPeer links the juggler to a juggler in another process, if configured
+func (a *App) connectPeer() error {
+	if a.config.PeerListen == "" && a.config.PeerAddress == "" {
+		return nil
+	}
+
+	opts := a.juggler.GetOptions()
+	opts.PassEvery = a.config.PassEvery
+	if err := a.juggler.SetOptions(opts); err != nil {
+		return err
+	}
+
+	node := peer.NewNode(a.juggler, a.config.PeerName)
+	a.webServer.SetPeer(node)
+
+	if a.config.PeerListen != "" {
+		if _, err := node.Listen(a.config.PeerListen); err != nil {
+			return err
+		}
+		fmt.Println(i18n.L("log.peer_waiting", a.config.PeerListen))
+		return nil
+	}
+
+	go node.KeepDialing(context.Background(), a.config.PeerAddress, peerRetry)
+	fmt.Println(i18n.L("log.peer_connecting", a.config.PeerAddress))
+	return nil
+}
+
+// startGRPC serves the gRPC API in the background, if configured
+func (a *App) startGRPC() error {
+	if a.config.GRPCPort == 0 {
+		return nil
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", a.config.GRPCPort))
+	if err != nil {
+		return err
+	}
+	service := grpcapi.NewService(a.webServer, a.juggler)
+	service.SetAuth(a.auth)
+	var opts []grpc.ServerOption
+	if a.tls != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(a.tls)))
+	}
+	server := grpcapi.NewServer(service, opts...)
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			log.Print(i18n.L("log.grpc_stopped", err))
+		}
+	}()
+	return nil
+}
+
+// setupTLS loads or generates the certificate when HTTPS is configured
+func (a *App) setupTLS() error {
+	if !a.config.TLS() {
+		return nil
+	}
+
+	var cert tls.Certificate
+	hstsMaxAge := a.config.HSTSMaxAge
+	if a.config.TLSSelfSigned {
+		var err error
+		if cert, err = web.SelfSignedCertificate(); err != nil {
+			return err
+		}
+		// Browsers would refuse plain HTTP on localhost for every other app too
+		hstsMaxAge = 0
+		fmt.Println(i18n.L("log.self_signed"))
+	} else {
+		var err error
+		if cert, err = tls.LoadX509KeyPair(a.config.TLSCert, a.config.TLSKey); err != nil {
+			return fmt.Errorf("loading TLS certificate: %v", err)
+		}
+	}
+
+	a.tls = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	a.webServer.SetTLS(a.tls, hstsMaxAge)
+	return nil
+}
+
+// startRedirect redirects plain HTTP to HTTPS in the background, if configured
+func (a *App) startRedirect() error {
+	if a.config.HTTPPort == 0 {
+		return nil
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", a.config.HTTPPort))
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:      web.RedirectHTTPS(a.config.WebPort),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			log.Print(i18n.L("log.redirect_stopped", err))
+		}
+	}()
+	return nil
+}
+
 // Run runs the application
 func (a *App) Run() error {
-	fmt.Printf("🤹 Жонглер готов к работе!\n")
-	fmt.Printf("Веб-интерфейс доступен по адресу: http://localhost:%d\n", a.config.WebPort)
-	fmt.Printf("Используйте веб-интерфейс для настройки и управления жонглированием.\n\n")
+	if a.config.Lang != "" {
+		if err := i18n.SetLocal(a.config.Lang); err != nil {
+			return err
+		}
+	}
+
+	if err := a.setupTLS(); err != nil {
+		return err
+	}
+
+	if a.config.AuthFile != "" {
+		authenticator, err := auth.Load(a.config.AuthFile)
+		if err != nil {
+			return err
+		}
+		for _, name := range authenticator.PlainPasswords() {
+			log.Print(i18n.L("log.plain_password", name))
+		}
+		a.auth = authenticator
+		a.webServer.SetAuth(authenticator)
+	}
+
+	if a.config.DevAssets != "" {
+		if err := a.webServer.SetDevAssets(a.config.DevAssets); err != nil {
+			return err
+		}
+		log.Print(i18n.L("log.dev_assets", a.config.DevAssets))
+	}
+
+	if a.config.LeaderboardFile != "" {
+		store, err := leaderboard.Open(a.config.LeaderboardFile)
+		if err != nil {
+			return err
+		}
+		a.webServer.SetLeaderboard(store)
+	}
+
+	if err := a.connectPeer(); err != nil {
+		return err
+	}
+	if err := a.startGRPC(); err != nil {
+		return err
+	}
+	if err := a.startRedirect(); err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.L("log.ready"))
+	scheme := "http"
+	if a.tls != nil {
+		scheme = "https"
+	}
+	fmt.Println(i18n.L("log.web_url", scheme, a.config.WebPort))
+	fmt.Println(i18n.L("log.embed_url", scheme, a.config.WebPort))
+	if a.config.HTTPPort != 0 {
+		fmt.Println(i18n.L("log.http_redirect", a.config.HTTPPort))
+	}
+	if a.config.GRPCPort != 0 {
+		fmt.Println(i18n.L("log.grpc_port", a.config.GRPCPort))
+	}
+	fmt.Print(i18n.L("log.use_web") + "\n\n")
 
 	// Start web server - this will block
 	a.webServer.Start()
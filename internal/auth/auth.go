@@ -0,0 +1,275 @@
+// Package auth authenticates API callers by static token or by username
+// and password, and keeps the login sessions of the web page
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Role decides what a caller may do; every role may do what the roles
+// before it may
+type Role string
+
+// Roles in order of privilege
+const (
+	Viewer   Role = "viewer"   // reads stats, players, exports and experiments
+	Operator Role = "operator" // also starts, stops and controls sessions
+	Admin    Role = "admin"    // also changes the engine options
+)
+
+// SessionTTL is how long a login session lasts
+const SessionTTL = 12 * time.Hour
+
+// Errors returned by the authenticator
+var (
+	ErrBadCredentials = errors.New("invalid username or password")
+	ErrNoSession      = errors.New("no such session")
+)
+
+// rank orders the roles; unknown roles rank below Viewer
+func (r Role) rank() int {
+	switch r {
+	case Viewer:
+		return 1
+	case Operator:
+		return 2
+	case Admin:
+		return 3
+	}
+	return 0
+}
+
+// Allows reports whether r may do what required may. An empty required
+// role allows everyone.
+func (r Role) Allows(required Role) bool {
+	return required == "" || r.rank() >= required.rank()
+}
+
+// Token is a static API token, sent as "Authorization: Bearer <token>"
+type Token struct {
+	Name  string `json:"name"` // who uses the token, shown as the identity
+	Token string `json:"token"`
+	Role  Role   `json:"role"`
+}
+
+// User can log in on the page. Password holds the password in plain text
+// or PasswordHash its bcrypt hash.
+type User struct {
+	Name         string `json:"name"`
+	Password     string `json:"password,omitempty"`
+	PasswordHash string `json:"password_hash,omitempty"`
+	Role         Role   `json:"role"`
+}
+
+// Config is the on-disk format of the auth file
+type Config struct {
+	Tokens []Token `json:"tokens"`
+	Users  []User  `json:"users"`
+}
+
+// Validate checks that every token and user has a name, a secret and a known role
+func (c Config) Validate() error {
+	if len(c.Tokens) == 0 && len(c.Users) == 0 {
+		return errors.New("no tokens or users configured")
+	}
+	for i, t := range c.Tokens {
+		if t.Token == "" || t.Role.rank() == 0 {
+			return fmt.Errorf("token %d: needs a token and a role of viewer, operator or admin", i+1)
+		}
+	}
+	names := make(map[string]bool)
+	for _, u := range c.Users {
+		if u.Name == "" || u.Role.rank() == 0 {
+			return fmt.Errorf("user %q: needs a name and a role of viewer, operator or admin", u.Name)
+		}
+		if (u.Password == "") == (u.PasswordHash == "") {
+			return fmt.Errorf("user %q: set either password or password_hash", u.Name)
+		}
+		if names[u.Name] {
+			return fmt.Errorf("user %q is listed twice", u.Name)
+		}
+		names[u.Name] = true
+	}
+	return nil
+}
+
+// Identity is an authenticated caller
+type Identity struct {
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+// Session is a login session of the page. CSRF must accompany every
+// request that changes state, as cookies are sent by any page.
+type Session struct {
+	ID       string
+	CSRF     string
+	Identity Identity
+	Expires  time.Time
+}
+
+// Authenticator checks credentials and keeps login sessions in memory
+type Authenticator struct {
+	config Config
+
+	mu       sync.Mutex
+	sessions map[string]Session
+	now      func() time.Time
+}
+
+// New creates an authenticator for a validated config
+func New(c Config) (*Authenticator, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &Authenticator{config: c, sessions: make(map[string]Session), now: time.Now}, nil
+}
+
+// Load reads the auth file at path
+func Load(path string) (*Authenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("reading auth file %s: %v", path, err)
+	}
+	a, err := New(c)
+	if err != nil {
+		return nil, fmt.Errorf("auth file %s: %v", path, err)
+	}
+	return a, nil
+}
+
+// CheckToken returns the identity of an API token. It compares the
+// digests of every token, so that the time taken reveals neither which
+// token matched nor the length of any.
+func (a *Authenticator) CheckToken(token string) (Identity, bool) {
+	digest := sha256.Sum256([]byte(token))
+	var id Identity
+	ok := false
+	for _, t := range a.config.Tokens {
+		known := sha256.Sum256([]byte(t.Token))
+		if subtle.ConstantTimeCompare(known[:], digest[:]) == 1 && !ok {
+			id, ok = Identity{Name: t.Name, Role: t.Role}, true
+		}
+	}
+	return id, ok
+}
+
+// PlainPasswords returns the names of the users whose password is kept in
+// plain text rather than as a bcrypt hash
+func (a *Authenticator) PlainPasswords() []string {
+	var names []string
+	for _, u := range a.config.Users {
+		if u.Password != "" {
+			names = append(names, u.Name)
+		}
+	}
+	return names
+}
+
+// dummyHash is compared against when no user has the given name, so that
+// a wrong name takes as long as a wrong password and does not reveal
+// which names exist
+var dummyHash = []byte("$2a$10$JGPp396NSLg6wXS5X7R35e4kbG/9mMGkWl1a1hKXPncn994sMeqBq")
+
+// CheckPassword returns the identity of a user with the given password
+func (a *Authenticator) CheckPassword(name, password string) (Identity, bool) {
+	for _, u := range a.config.Users {
+		if u.Name != name {
+			continue
+		}
+		if u.PasswordHash != "" {
+			if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) != nil {
+				return Identity{}, false
+			}
+		} else if subtle.ConstantTimeCompare([]byte(u.Password), []byte(password)) != 1 {
+			return Identity{}, false
+		}
+		return Identity{Name: u.Name, Role: u.Role}, true
+	}
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+	return Identity{}, false
+}
+
+// Login checks a user's password and starts a session for them
+func (a *Authenticator) Login(name, password string) (Session, error) {
+	id, ok := a.CheckPassword(name, password)
+	if !ok {
+		return Session{}, ErrBadCredentials
+	}
+
+	s := Session{ID: randomToken(), CSRF: randomToken(), Identity: id, Expires: a.now().Add(SessionTTL)}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.dropExpired()
+	a.sessions[s.ID] = s
+	return s, nil
+}
+
+// Session returns the live session with the given ID
+func (a *Authenticator) Session(id string) (Session, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s, ok := a.sessions[id]
+	if !ok {
+		return Session{}, ErrNoSession
+	}
+	if !a.now().Before(s.Expires) {
+		delete(a.sessions, id)
+		return Session{}, ErrNoSession
+	}
+	return s, nil
+}
+
+// Logout ends a session
+func (a *Authenticator) Logout(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.sessions, id)
+}
+
+// SetClock replaces the clock used for session expiry, for tests
+func (a *Authenticator) SetClock(now func() time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.now = now
+}
+
+// dropExpired forgets expired sessions. The caller must hold the lock.
+func (a *Authenticator) dropExpired() {
+	now := a.now()
+	for id, s := range a.sessions {
+		if !now.Before(s.Expires) {
+			delete(a.sessions, id)
+		}
+	}
+}
+
+// ValidCSRF reports whether token is the session's CSRF token
+func (s Session) ValidCSRF(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(s.CSRF), []byte(token)) == 1
+}
+
+// randomToken returns 32 random bytes encoded for use in cookies and headers
+func randomToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,201 @@
+package batch
+
+import (
+	"context"
+	"io"
+	"math"
+	"runtime"
+	"sort"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"juggler/internal/i18n"
+	"juggler/internal/juggler"
+)
+
+// Params describes a batch of identical simulated sessions
+type Params struct {
+	Balls   int             `json:"balls"`
+	Minutes int             `json:"minutes"`
+	Runs    int             `json:"runs"`
+	Seed    int64           `json:"seed"`
+	Options juggler.Options `json:"options"`
+}
+
+// Validate validates the batch parameters
+func (p Params) Validate() error {
+	if p.Balls <= 0 || p.Minutes <= 0 {
+		return i18n.NewError("batch.balls_minutes")
+	}
+	if p.Runs <= 0 {
+		return i18n.NewError("batch.runs")
+	}
+	return p.Options.Validate()
+}
+
+// Metric holds the distribution of one figure across all sessions
+type Metric struct {
+	Name string  `json:"name"`
+	Mean float64 `json:"mean"`
+	Min  float64 `json:"min"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// Report is the aggregated result of a batch
+type Report struct {
+	Params   Params        `json:"params"`
+	Elapsed  time.Duration `json:"elapsed"`
+	DropRate float64       `json:"drop_rate"` // share of sessions with at least one drop
+	Metrics  []Metric      `json:"metrics"`
+}
+
+// Run simulates p.Runs sessions on virtual clocks, spread over all CPUs,
+// and aggregates their summaries. Session i is seeded with p.Seed+i so a
+// batch is reproducible regardless of scheduling.
+func Run(p Params) (*Report, error) {
+	return RunContext(context.Background(), p)
+}
+
+// RunContext is Run, giving up with the context's error once ctx is done;
+// sessions already started run to their end
+func RunContext(ctx context.Context, p Params) (*Report, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	summaries := make([]juggler.Summary, p.Runs)
+
+	eg := &errgroup.Group{}
+	eg.SetLimit(runtime.GOMAXPROCS(0))
+	for i := 0; i < p.Runs; i++ {
+		eg.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			s, err := Simulate(p.Balls, p.Minutes, p.Options, p.Seed+int64(i))
+			summaries[i] = s
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return Aggregate(p, summaries, time.Since(started)), nil
+}
+
+// Simulate runs one session on a virtual clock and returns its summary
+func Simulate(balls, minutes int, opts juggler.Options, seed int64) (juggler.Summary, error) {
+	events, err := SimulateEvents(balls, minutes, opts, seed)
+	if err != nil {
+		return juggler.Summary{}, err
+	}
+	return juggler.Summarize(events), nil
+}
+
+// SimulateEvents runs one session on a virtual clock and returns its events
+func SimulateEvents(balls, minutes int, opts juggler.Options, seed int64) ([]juggler.Event, error) {
+	clock := juggler.NewVirtualClock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetClock(clock)
+	j.SetSeed(seed)
+	if err := j.SetOptions(opts); err != nil {
+		return nil, err
+	}
+
+	j.Reset(balls, minutes)
+	j.Simulate(clock)
+
+	return j.GetEvents(), nil
+}
+
+// Aggregate builds a report from per-session summaries
+func Aggregate(p Params, summaries []juggler.Summary, elapsed time.Duration) *Report {
+	r := &Report{
+		Params:  p,
+		Elapsed: elapsed,
+	}
+
+	withDrops := 0
+	for _, s := range summaries {
+		if s.Drops > 0 {
+			withDrops++
+		}
+	}
+	if len(summaries) > 0 {
+		r.DropRate = float64(withDrops) / float64(len(summaries))
+	}
+
+	figures := []struct {
+		name  string
+		value func(juggler.Summary) float64
+	}{
+		{"throws", func(s juggler.Summary) float64 { return float64(s.Throws) }},
+		{"catches", func(s juggler.Summary) float64 { return float64(s.Catches) }},
+		{"drops", func(s juggler.Summary) float64 { return float64(s.Drops) }},
+		{"longest_streak", func(s juggler.Summary) float64 { return float64(s.LongestStreak) }},
+		{"utilization", func(s juggler.Summary) float64 { return s.Utilization }},
+		{"avg_in_air", func(s juggler.Summary) float64 { return s.AvgInAir }},
+		{"idle_hand_time", func(s juggler.Summary) float64 { return s.IdleHandTime.Seconds() }},
+	}
+
+	for _, f := range figures {
+		values := make([]float64, len(summaries))
+		for i, s := range summaries {
+			values[i] = f.value(s)
+		}
+		r.Metrics = append(r.Metrics, Distribution(f.name, values))
+	}
+
+	return r
+}
+
+// Metric returns the metric with the given name, or a zero metric if there is none
+func (r *Report) Metric(name string) Metric {
+	for _, m := range r.Metrics {
+		if m.Name == name {
+			return m
+		}
+	}
+	return Metric{Name: name}
+}
+
+// Distribution computes mean, extremes and nearest-rank percentiles of values
+func Distribution(name string, values []float64) Metric {
+	m := Metric{Name: name}
+	if len(values) == 0 {
+		return m
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+
+	m.Mean = sum / float64(len(sorted))
+	m.Min = sorted[0]
+	m.Max = sorted[len(sorted)-1]
+	m.P50 = percentile(sorted, 50)
+	m.P90 = percentile(sorted, 90)
+	m.P99 = percentile(sorted, 99)
+	return m
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,69 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteText writes the report as a human-readable table
+func (r *Report) WriteText(w io.Writer) error {
+	p := r.Params
+	o := p.Options
+
+	fmt.Fprintf(w, "=== Batch Report ===\n")
+	fmt.Fprintf(w, "Sessions: %d x %d balls for %d minutes (seed %d)\n", p.Runs, p.Balls, p.Minutes, p.Seed)
+	fmt.Fprintf(w, "Flight time: %d-%d seconds, throw interval: %v, drop chance: %.3f\n",
+		o.MinFlightTime, o.MaxFlightTime, o.ThrowInterval, o.DropChance)
+	fmt.Fprintf(w, "Simulated in: %v\n", r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Sessions with drops: %.1f%%\n\n", r.DropRate*100)
+
+	fmt.Fprintf(w, "%-16s %10s %10s %10s %10s %10s %10s\n", "metric", "mean", "min", "p50", "p90", "p99", "max")
+	for _, m := range r.Metrics {
+		fmt.Fprintf(w, "%-16s %10.2f %10.2f %10.2f %10.2f %10.2f %10.2f\n", m.Name, m.Mean, m.Min, m.P50, m.P90, m.P99, m.Max)
+	}
+
+	_, err := fmt.Fprintf(w, "====================\n")
+	return err
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes one row per metric, preceded by a header row
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"metric", "mean", "min", "p50", "p90", "p99", "max"})
+	for _, m := range r.Metrics {
+		cw.Write([]string{m.Name, formatFloat(m.Mean), formatFloat(m.Min), formatFloat(m.P50), formatFloat(m.P90), formatFloat(m.P99), formatFloat(m.Max)})
+	}
+	cw.Write([]string{"drop_rate", formatFloat(r.DropRate), "", "", "", "", ""})
+	cw.Flush()
+	return cw.Error()
+}
+
+// Write writes the report in the named format: "text", "json" or "csv"
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case "text":
+		return r.WriteText(w)
+	case "json":
+		return r.WriteJSON(w)
+	case "csv":
+		return r.WriteCSV(w)
+	default:
+		return fmt.Errorf("unknown format %q, expected text, json or csv", format)
+	}
+}
+
+// formatFloat formats a value for CSV with no superfluous digits
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
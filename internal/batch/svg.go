@@ -0,0 +1,134 @@
+package batch
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ChartMetric describes a sweep figure that can be charted
+type ChartMetric struct {
+	Name  string
+	Title string
+	Value func(Row) float64
+}
+
+// ChartMetrics lists the figures WriteSVG can plot
+var ChartMetrics = []ChartMetric{
+	{"drop_rate", "Sessions with drops (%)", func(r Row) float64 { return r.DropRate * 100 }},
+	{"avg_in_air", "Average balls in the air", func(r Row) float64 { return r.AvgInAir }},
+	{"idle_hand_time", "Idle hand time per session (s)", func(r Row) float64 { return r.IdleHandTime }},
+}
+
+// chartColors is the palette used for series, repeated when there are more series
+var chartColors = []string{"#007bff", "#dc3545", "#28a745", "#fd7e14", "#6f42c1", "#20c997", "#e83e8c", "#6c757d"}
+
+// Chart geometry in pixels
+const (
+	chartWidth     = 720
+	chartHeight    = 360
+	chartLeft      = 60
+	chartRight     = 20
+	chartTop       = 40
+	chartBottom    = 50
+	legendLineSize = 18
+)
+
+// WriteSVG plots the named metric against the ball count as an SVG line
+// chart with one series per combination of the other parameters
+func (s *Sweep) WriteSVG(w io.Writer, metric string) error {
+	var cm *ChartMetric
+	for i := range ChartMetrics {
+		if ChartMetrics[i].Name == metric {
+			cm = &ChartMetrics[i]
+		}
+	}
+	if cm == nil {
+		return fmt.Errorf("unknown chart metric %q", metric)
+	}
+
+	// Collect ball counts for the x axis and series in order of appearance
+	ballSet := make(map[int]bool)
+	var variants []string
+	series := make(map[string][]Row)
+	maxValue := 0.0
+	for _, r := range s.Rows {
+		ballSet[r.Balls] = true
+		v := r.Variant()
+		if _, ok := series[v]; !ok {
+			variants = append(variants, v)
+		}
+		series[v] = append(series[v], r)
+		if cm.Value(r) > maxValue {
+			maxValue = cm.Value(r)
+		}
+	}
+	balls := make([]int, 0, len(ballSet))
+	for b := range ballSet {
+		balls = append(balls, b)
+	}
+	sort.Ints(balls)
+	if maxValue == 0 {
+		maxValue = 1
+	}
+	maxValue *= 1.1
+
+	plotWidth := float64(chartWidth - chartLeft - chartRight)
+	plotHeight := float64(chartHeight - chartTop - chartBottom)
+	xOf := func(b int) float64 {
+		i := sort.SearchInts(balls, b)
+		if len(balls) == 1 {
+			return chartLeft + plotWidth/2
+		}
+		return chartLeft + plotWidth*float64(i)/float64(len(balls)-1)
+	}
+	yOf := func(v float64) float64 {
+		return chartTop + plotHeight*(1-v/maxValue)
+	}
+
+	height := chartHeight + legendLineSize*len(variants)
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Arial, sans-serif" font-size="12">`+"\n",
+		chartWidth, height, chartWidth, height)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	fmt.Fprintf(&b, `<text x="%d" y="24" text-anchor="middle" font-size="16" font-weight="bold">%s</text>`+"\n",
+		chartWidth/2, html.EscapeString(cm.Title))
+
+	// Axes, horizontal grid lines and labels
+	for i := 0; i <= 5; i++ {
+		v := maxValue * float64(i) / 5
+		y := yOf(v)
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#e9ecef"/>`+"\n", chartLeft, y, chartWidth-chartRight, y)
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end" dominant-baseline="middle">%.2f</text>`+"\n", chartLeft-6, y, v)
+	}
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#495057"/>`+"\n", chartLeft, chartTop, chartLeft, chartHeight-chartBottom)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#495057"/>`+"\n", chartLeft, chartHeight-chartBottom, chartWidth-chartRight, chartHeight-chartBottom)
+	for _, n := range balls {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%d</text>`+"\n", xOf(n), chartHeight-chartBottom+16, n)
+	}
+	fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">balls</text>`+"\n", chartLeft+plotWidth/2, chartHeight-chartBottom+34)
+
+	// Series with legend below the plot
+	for i, v := range variants {
+		color := chartColors[i%len(chartColors)]
+		points := make([]string, 0, len(series[v]))
+		for _, r := range series[v] {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", xOf(r.Balls), yOf(cm.Value(r))))
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`+"\n", color, strings.Join(points, " "))
+		for _, r := range series[v] {
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%d balls: %.2f</title></circle>`+"\n",
+				xOf(r.Balls), yOf(cm.Value(r)), color, r.Balls, cm.Value(r))
+		}
+
+		y := chartHeight + legendLineSize*i
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="12" height="12" fill="%s"/>`+"\n", chartLeft, y, color)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", chartLeft+18, y+10, html.EscapeString(v))
+	}
+
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,208 @@
+package batch
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"juggler/internal/i18n"
+	"juggler/internal/juggler"
+)
+
+// maxSweepSessions bounds the total number of sessions a single sweep may simulate
+const maxSweepSessions = 200000
+
+// FlightRange is an inclusive range of flight times in seconds
+type FlightRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// String returns the range as "min-max"
+func (f FlightRange) String() string {
+	return fmt.Sprintf("%d-%d", f.Min, f.Max)
+}
+
+// Grid describes the parameter values to sweep; every combination is simulated
+type Grid struct {
+	Balls          []int           `json:"balls"`
+	FlightRanges   []FlightRange   `json:"flight_ranges"`
+	ThrowIntervals []time.Duration `json:"throw_intervals"`
+	DropChances    []float64       `json:"drop_chances"`
+	Minutes        int             `json:"minutes"`
+	Runs           int             `json:"runs"` // sessions per configuration
+	Seed           int64           `json:"seed"`
+}
+
+// Size returns the number of configurations in the grid
+func (g Grid) Size() int {
+	return len(g.Balls) * len(g.FlightRanges) * len(g.ThrowIntervals) * len(g.DropChances)
+}
+
+// Validate validates the grid
+func (g Grid) Validate() error {
+	if g.Size() == 0 {
+		return i18n.NewError("batch.empty_parameter")
+	}
+	if g.Minutes <= 0 || g.Runs <= 0 {
+		return i18n.NewError("batch.minutes_runs")
+	}
+	if g.Size()*g.Runs > maxSweepSessions {
+		return i18n.NewError("batch.too_large", g.Size(), g.Runs, maxSweepSessions)
+	}
+
+	for _, p := range g.Params() {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Params expands the grid into batch parameters, ball count varying slowest
+func (g Grid) Params() []Params {
+	params := make([]Params, 0, g.Size())
+	for _, balls := range g.Balls {
+		for _, fr := range g.FlightRanges {
+			for _, interval := range g.ThrowIntervals {
+				for _, drop := range g.DropChances {
+					params = append(params, Params{
+						Balls:   balls,
+						Minutes: g.Minutes,
+						Runs:    g.Runs,
+						Seed:    g.Seed,
+						Options: juggler.Options{
+							MinFlightTime: fr.Min,
+							MaxFlightTime: fr.Max,
+							ThrowInterval: interval,
+							DropChance:    drop,
+						},
+					})
+				}
+			}
+		}
+	}
+	return params
+}
+
+// Row is the outcome of one configuration of a sweep
+type Row struct {
+	Balls         int           `json:"balls"`
+	FlightRange   FlightRange   `json:"flight_range"`
+	ThrowInterval time.Duration `json:"throw_interval"`
+	DropChance    float64       `json:"drop_chance"`
+	DropRate      float64       `json:"drop_rate"`      // share of sessions with at least one drop
+	AvgInAir      float64       `json:"avg_in_air"`     // mean balls in the air
+	IdleHandTime  float64       `json:"idle_hand_time"` // mean seconds balls waited in hand per session
+	Report        *Report       `json:"report"`
+}
+
+// Variant describes the non-ball parameters of the row; rows sharing a
+// variant form one series in the charts
+func (r Row) Variant() string {
+	return fmt.Sprintf("flight %s s, every %v, drop %g", r.FlightRange, r.ThrowInterval, r.DropChance)
+}
+
+// Sweep is the result of simulating every configuration of a grid
+type Sweep struct {
+	Grid    Grid          `json:"grid"`
+	Elapsed time.Duration `json:"elapsed"`
+	Rows    []Row         `json:"rows"`
+}
+
+// RunSweep runs a batch for every configuration of the grid
+func RunSweep(g Grid) (*Sweep, error) {
+	return RunSweepContext(context.Background(), g)
+}
+
+// RunSweepContext is RunSweep, giving up with the context's error once ctx is done
+func RunSweepContext(ctx context.Context, g Grid) (*Sweep, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	s := &Sweep{Grid: g}
+
+	for _, p := range g.Params() {
+		report, err := RunContext(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Rows = append(s.Rows, Row{
+			Balls:         p.Balls,
+			FlightRange:   FlightRange{p.Options.MinFlightTime, p.Options.MaxFlightTime},
+			ThrowInterval: p.Options.ThrowInterval,
+			DropChance:    p.Options.DropChance,
+			DropRate:      report.DropRate,
+			AvgInAir:      report.Metric("avg_in_air").Mean,
+			IdleHandTime:  report.Metric("idle_hand_time").Mean,
+			Report:        report,
+		})
+	}
+
+	s.Elapsed = time.Since(started)
+	return s, nil
+}
+
+// WriteText writes the comparison table
+func (s *Sweep) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "=== Parameter Sweep ===\n")
+	fmt.Fprintf(w, "%d configurations x %d runs of %d minutes (seed %d), simulated in %v\n\n",
+		len(s.Rows), s.Grid.Runs, s.Grid.Minutes, s.Grid.Seed, s.Elapsed.Round(time.Millisecond))
+
+	fmt.Fprintf(w, "%5s %8s %10s %8s %10s %10s %10s\n", "balls", "flight", "interval", "drop", "drop rate", "in air", "idle hand")
+	for _, r := range s.Rows {
+		fmt.Fprintf(w, "%5d %8s %10v %8g %9.1f%% %10.2f %9.1fs\n",
+			r.Balls, r.FlightRange, r.ThrowInterval, r.DropChance, r.DropRate*100, r.AvgInAir, r.IdleHandTime)
+	}
+
+	_, err := fmt.Fprintf(w, "=======================\n")
+	return err
+}
+
+// WriteJSON writes the sweep, including every configuration's full report
+func (s *Sweep) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// WriteCSV writes one row per configuration
+func (s *Sweep) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"balls", "min_flight", "max_flight", "throw_interval_ms", "drop_chance", "drop_rate", "avg_in_air", "idle_hand_time"})
+	for _, r := range s.Rows {
+		cw.Write([]string{
+			strconv.Itoa(r.Balls),
+			strconv.Itoa(r.FlightRange.Min),
+			strconv.Itoa(r.FlightRange.Max),
+			strconv.FormatInt(r.ThrowInterval.Milliseconds(), 10),
+			formatFloat(r.DropChance),
+			formatFloat(r.DropRate),
+			formatFloat(r.AvgInAir),
+			formatFloat(r.IdleHandTime),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Write writes the sweep in the named format: "text", "json" or "csv"
+func (s *Sweep) Write(w io.Writer, format string) error {
+	switch format {
+	case "text":
+		return s.WriteText(w)
+	case "json":
+		return s.WriteJSON(w)
+	case "csv":
+		return s.WriteCSV(w)
+	default:
+		return fmt.Errorf("unknown format %q, expected text, json or csv", format)
+	}
+}
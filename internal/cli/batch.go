@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"juggler/internal/batch"
+	"juggler/internal/juggler"
+)
+
+// runBatch simulates many sessions in virtual time and prints aggregate statistics
+func runBatch(args []string, stdout, stderr io.Writer) error {
+	opts := juggler.DefaultOptions()
+	p := batch.Params{}
+
+	fs := newFlagSet("batch", stderr)
+	fs.IntVar(&p.Runs, "runs", 100, "number of sessions to simulate")
+	fs.IntVar(&p.Balls, "balls", 3, "number of balls")
+	fs.IntVar(&p.Minutes, "minutes", 2, "session length in minutes")
+	fs.Int64Var(&p.Seed, "seed", 1, "random seed of the first session")
+	fs.IntVar(&opts.MinFlightTime, "min-flight", opts.MinFlightTime, "shortest flight in seconds")
+	fs.IntVar(&opts.MaxFlightTime, "max-flight", opts.MaxFlightTime, "longest flight in seconds")
+	fs.DurationVar(&opts.ThrowInterval, "throw-interval", opts.ThrowInterval, "how often balls in hand are thrown")
+	fs.Float64Var(&opts.DropChance, "drop-chance", 0.01, "probability that a landing ball is dropped")
+	format := fs.String("format", "text", "report format: text, json or csv")
+	output := fs.String("output", "", "write the report to this file instead of standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	p.Options = opts
+
+	report, err := batch.Run(p)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return report.Write(stdout, *format)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := report.Write(f, *format); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Report written to %s\n", *output)
+	return nil
+}
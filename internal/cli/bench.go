@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"juggler/internal/juggler"
+)
+
+// runBench stresses the engine by repeatedly throwing every ball while
+// concurrent readers poll statistics, and reports the achieved throughput
+func runBench(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("bench", stderr)
+	balls := fs.Int("balls", 100, "number of balls thrown per round")
+	duration := fs.Duration("duration", 5*time.Second, "how long to run the benchmark")
+	readers := fs.Int("readers", 4, "number of concurrent statistics readers")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *balls <= 0 || *duration <= 0 || *readers < 0 {
+		return fmt.Errorf("balls and duration must be positive, readers must not be negative")
+	}
+
+	j := juggler.NewJuggler(0, 0)
+
+	var reads atomic.Int64
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < *readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					j.GetStats()
+					reads.Add(1)
+				}
+			}
+		}()
+	}
+
+	fmt.Fprintf(stdout, "Benchmarking %d balls with %d readers for %v...\n", *balls, *readers, *duration)
+
+	rounds, throws := 0, 0
+	start := time.Now()
+	for time.Since(start) < *duration {
+		ctx, cancel := context.WithCancel(context.Background())
+		eg := &errgroup.Group{}
+
+		j.Reset(*balls, 1)
+		for j.ThrowBall(ctx, eg) {
+			throws++
+		}
+
+		cancel()
+		eg.Wait()
+		rounds++
+	}
+	elapsed := time.Since(start)
+
+	close(stop)
+	wg.Wait()
+	j.Stop()
+
+	fmt.Fprintf(stdout, "\n=== Benchmark Results ===\n")
+	fmt.Fprintf(stdout, "Duration: %v\n", elapsed.Round(time.Millisecond))
+	fmt.Fprintf(stdout, "Rounds: %d\n", rounds)
+	fmt.Fprintf(stdout, "Throws: %d (%.0f/s)\n", throws, float64(throws)/elapsed.Seconds())
+	fmt.Fprintf(stdout, "Stats reads: %d (%.0f/s)\n", reads.Load(), float64(reads.Load())/elapsed.Seconds())
+	fmt.Fprintf(stdout, "=========================\n")
+	return nil
+}
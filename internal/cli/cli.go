@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// command describes a single subcommand of the juggler binary
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) error
+}
+
+// commands lists the available subcommands in the order they are shown in help.
+// It is filled in init because the commands themselves look it up for --help.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"serve", "serve [--port N]", "start the web interface (default)", runServe},
+		{"run", "run [--balls N] [--minutes N]", "run a headless simulation and print a summary", runRun},
+		{"tui", "tui [--balls N] [--minutes N]", "show an interactive terminal dashboard", runTUI},
+		{"batch", "batch [--runs N] [--balls N] [--drop-chance P] [--format F]", "simulate many sessions in virtual time and report statistics", runBatch},
+		{"sweep", "sweep [--balls 1-7] [--flight 5-10] [--throw-interval 500ms] [--drop-chance 0.01] [--charts DIR]", "compare simulated sessions over a grid of parameters", runSweep},
+		{"replay", "replay [--speed X] <file>", "replay a session recorded with run --record", runReplay},
+		{"validate", "validate <siteswap>", "check whether a siteswap pattern is jugglable", runValidate},
+		{"bench", "bench [--balls N] [--duration D]", "stress the juggling engine", runBench},
+	}
+}
+
+// Run executes the subcommand named by args and returns the process exit code.
+// With no subcommand, or a bare port number, the web server is started.
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		return exitCode(runServe(nil, stdout, stderr), stderr)
+	}
+
+	// Backwards compatibility: "juggler 9000" starts the server on port 9000
+	if _, err := strconv.Atoi(args[0]); err == nil {
+		return exitCode(runServe([]string{"--port", args[0]}, stdout, stderr), stderr)
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		printUsage(stdout)
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return exitCode(cmd.run(args[1:], stdout, stderr), stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+	printUsage(stderr)
+	return 2
+}
+
+// exitCode reports err and converts it into a process exit code
+func exitCode(err error, stderr io.Writer) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+}
+
+// errUsage is returned when arguments are invalid and usage has already been printed
+var errUsage = errors.New("invalid usage")
+
+// printUsage prints the list of subcommands
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: juggler <command> [options]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'juggler <command> --help' for details about a command.")
+	fmt.Fprintln(w, "Running juggler without a command starts the web interface on port 8080.")
+}
+
+// newFlagSet creates a flag set whose --help output describes the named command
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		fs.Usage = func() {
+			fmt.Fprintf(stderr, "Usage: juggler %s\n\n", cmd.usage)
+			fmt.Fprintf(stderr, "%s\n", capitalize(cmd.summary))
+			if hasFlags(fs) {
+				fmt.Fprintln(stderr)
+				fmt.Fprintln(stderr, "Options:")
+				fs.PrintDefaults()
+			}
+		}
+	}
+
+	return fs
+}
+
+// hasFlags reports whether any flags are defined on fs
+func hasFlags(fs *flag.FlagSet) bool {
+	found := false
+	fs.VisitAll(func(*flag.Flag) { found = true })
+	return found
+}
+
+// capitalize upper-cases the first letter of an ASCII sentence
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
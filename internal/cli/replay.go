@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"juggler/internal/juggler"
+)
+
+// runReplay prints the events of a recorded session, optionally paced in real time
+func runReplay(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("replay", stderr)
+	speed := fs.Float64("speed", 0, "playback speed multiplier (0 prints events immediately)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if *speed < 0 {
+		return fmt.Errorf("speed must not be negative")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	events, err := juggler.ReadEvents(f)
+	if err != nil {
+		return fmt.Errorf("reading %s: %v", fs.Arg(0), err)
+	}
+
+	var prev time.Time
+	for i, e := range events {
+		if i == 0 {
+			prev = e.Time
+		}
+		if *speed > 0 {
+			time.Sleep(time.Duration(float64(e.Time.Sub(prev)) / *speed))
+		}
+		prev = e.Time
+
+		offset := e.Time.Sub(events[0].Time).Seconds()
+		switch e.Type {
+		case "throw":
+			fmt.Fprintf(stdout, "[%7.1fs] Ball %d thrown (%d sec flight)\n", offset, e.BallID, e.FlightTime)
+		default:
+			fmt.Fprintf(stdout, "[%7.1fs] Ball %d %s\n", offset, e.BallID, e.Type)
+		}
+	}
+
+	printSummary(stdout, juggler.Summarize(events))
+	return nil
+}
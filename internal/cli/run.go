@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"juggler/internal/juggler"
+)
+
+// runRun juggles in the foreground without the web interface,
+// printing statistics periodically and a summary at the end
+func runRun(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("run", stderr)
+	balls := fs.Int("balls", 3, "number of balls")
+	minutes := fs.Int("minutes", 1, "juggling time in minutes")
+	interval := fs.Duration("interval", 2*time.Second, "how often to print statistics")
+	record := fs.String("record", "", "write session events to this file for replay")
+	bell := fs.String("bell", "", "ring the terminal bell on these events, e.g. throw,drop")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *balls <= 0 || *minutes <= 0 {
+		return fmt.Errorf("balls and minutes must be positive")
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	ringOn, err := parseBell(*bell)
+	if err != nil {
+		return err
+	}
+
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.Reset(*balls, *minutes)
+	j.Start()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	// Events are checked often so that the bell keeps the engine's rhythm
+	var bellTicker <-chan time.Time
+	if len(ringOn) > 0 {
+		t := time.NewTicker(bellInterval)
+		defer t.Stop()
+		bellTicker = t.C
+	}
+	seen := 0
+
+	fmt.Fprintf(stdout, "Juggling %d balls for %d minutes...\n", *balls, *minutes)
+
+loop:
+	for {
+		select {
+		case <-j.Done():
+			break loop
+		case <-interrupt:
+			fmt.Fprintln(stdout, "Interrupted, waiting for balls in the air to land...")
+			j.Stop()
+		case <-ticker.C:
+			j.WriteStats(stdout)
+		case <-bellTicker:
+			_, events := j.GetEventsSince(seen)
+			seen += len(events)
+			for _, e := range events {
+				if ringOn[e.Type] {
+					fmt.Fprint(stdout, "\a")
+				}
+			}
+		}
+	}
+
+	events := j.GetEvents()
+	if *record != "" {
+		if err := writeEventsFile(*record, events); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Session recorded to %s\n", *record)
+	}
+
+	printSummary(stdout, juggler.Summarize(events))
+	return nil
+}
+
+// bellInterval is how often run --bell looks for new events
+const bellInterval = 20 * time.Millisecond
+
+// parseBell parses the --bell list of event types
+func parseBell(list string) (map[string]bool, error) {
+	ringOn := make(map[string]bool)
+	for _, typ := range strings.Split(list, ",") {
+		switch typ = strings.TrimSpace(typ); typ {
+		case "":
+		case "throw", "catch", "drop":
+			ringOn[typ] = true
+		default:
+			return nil, fmt.Errorf("bell event %q must be throw, catch or drop", typ)
+		}
+	}
+	return ringOn, nil
+}
+
+// writeEventsFile stores events in path as JSON lines
+func writeEventsFile(path string, events []juggler.Event) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := juggler.WriteEvents(f, events); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// printSummary prints the summary of a session
+func printSummary(w io.Writer, s juggler.Summary) {
+	fmt.Fprintf(w, "\n=== Session Summary ===\n")
+	fmt.Fprintf(w, "Duration: %.0f seconds\n", s.Duration.Seconds())
+	fmt.Fprintf(w, "Balls: %d\n", s.Balls)
+	fmt.Fprintf(w, "Throws: %d\n", s.Throws)
+	fmt.Fprintf(w, "Catches: %d\n", s.Catches)
+	fmt.Fprintf(w, "Drops: %d\n", s.Drops)
+	fmt.Fprintf(w, "Longest streak: %d\n", s.LongestStreak)
+	fmt.Fprintf(w, "Utilization: %.1f%%\n", s.Utilization*100)
+	fmt.Fprintf(w, "=======================\n")
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"io"
+	"strings"
+
+	"juggler/internal/app"
+	"juggler/internal/config"
+	"juggler/internal/i18n"
+)
+
+// runServe starts the web interface, blocking until the server stops
+func runServe(args []string, stdout, stderr io.Writer) error {
+	cfg := config.DefaultConfig()
+
+	fs := newFlagSet("serve", stderr)
+	fs.IntVar(&cfg.WebPort, "port", cfg.WebPort, "port for the web server")
+	fs.IntVar(&cfg.GRPCPort, "grpc-port", cfg.GRPCPort, "port for the gRPC API, 0 to turn it off")
+	fs.StringVar(&cfg.LeaderboardFile, "leaderboard", cfg.LeaderboardFile, "file storing player profiles and runs, empty to keep them in memory")
+	fs.StringVar(&cfg.AuthFile, "auth", cfg.AuthFile, "file with API tokens and users allowed to use the API, empty to allow everyone")
+	fs.Float64Var(&cfg.RateLimit, "rate-limit", cfg.RateLimit, "API requests per second allowed from each client, 0 for no limit")
+	fs.IntVar(&cfg.RateBurst, "rate-burst", cfg.RateBurst, "API requests a client may make at once before the rate limit applies")
+	fs.Int64Var(&cfg.MaxBodyBytes, "max-body", cfg.MaxBodyBytes, "largest request body accepted, in bytes")
+	fs.StringVar(&cfg.TLSCert, "tls-cert", cfg.TLSCert, "PEM certificate file to serve HTTPS with")
+	fs.StringVar(&cfg.TLSKey, "tls-key", cfg.TLSKey, "PEM private key file of the certificate")
+	fs.BoolVar(&cfg.TLSSelfSigned, "tls-self-signed", cfg.TLSSelfSigned, "serve HTTPS with a certificate generated at startup, for local use")
+	fs.IntVar(&cfg.HTTPPort, "http-port", cfg.HTTPPort, "plain HTTP port redirecting to HTTPS, 0 for none")
+	fs.DurationVar(&cfg.HSTSMaxAge, "hsts-max-age", cfg.HSTSMaxAge, "how long browsers must use HTTPS only, 0 to not send HSTS")
+	fs.Func("cors-origins", "comma-separated origins allowed to call the API and embed the view, * for any", func(s string) error {
+		for _, origin := range strings.Split(s, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				cfg.CORSOrigins = append(cfg.CORSOrigins, origin)
+			}
+		}
+		return nil
+	})
+	fs.StringVar(&cfg.Lang, "lang", cfg.Lang, "language of logs and console output: "+strings.Join(i18n.Languages(), ", "))
+	fs.StringVar(&cfg.DevAssets, "dev-assets", cfg.DevAssets, "serve templates and static files from this directory, e.g. internal/web/assets, rereading them on every request")
+	fs.StringVar(&cfg.PeerListen, "peer-listen", cfg.PeerListen, "address to accept a peer juggler on, e.g. :7070")
+	fs.StringVar(&cfg.PeerAddress, "peer", cfg.PeerAddress, "address of a peer juggler to connect to, e.g. localhost:7070")
+	fs.StringVar(&cfg.PeerName, "peer-name", cfg.PeerName, "name this juggler gives the peer")
+	fs.IntVar(&cfg.PassEvery, "pass-every", cfg.PassEvery, "pass every Nth throw to the peer")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	return app.NewApp(cfg).Run()
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"juggler/internal/batch"
+)
+
+// runSweep simulates every combination of the given parameter lists and
+// prints a comparison table, optionally writing SVG charts
+func runSweep(args []string, stdout, stderr io.Writer) error {
+	g := batch.Grid{}
+
+	fs := newFlagSet("sweep", stderr)
+	balls := fs.String("balls", "1-7", "ball counts, e.g. 1-7 or 3,5,7")
+	flights := fs.String("flight", "5-10", "flight time ranges in seconds, e.g. 5-10,3-6")
+	intervals := fs.String("throw-interval", "500ms", "throw intervals, e.g. 500ms,1s")
+	drops := fs.String("drop-chance", "0.01", "drop probabilities, e.g. 0,0.01,0.05")
+	fs.IntVar(&g.Minutes, "minutes", 2, "session length in minutes")
+	fs.IntVar(&g.Runs, "runs", 20, "sessions per configuration")
+	fs.Int64Var(&g.Seed, "seed", 1, "random seed of the first session")
+	format := fs.String("format", "text", "table format: text, json or csv")
+	output := fs.String("output", "", "write the table to this file instead of standard output")
+	charts := fs.String("charts", "", "directory to write SVG charts to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var err error
+	if g.Balls, err = parseIntList(*balls); err != nil {
+		return fmt.Errorf("--balls: %v", err)
+	}
+	if g.FlightRanges, err = parseFlightRanges(*flights); err != nil {
+		return fmt.Errorf("--flight: %v", err)
+	}
+	if g.ThrowIntervals, err = parseDurations(*intervals); err != nil {
+		return fmt.Errorf("--throw-interval: %v", err)
+	}
+	if g.DropChances, err = parseFloats(*drops); err != nil {
+		return fmt.Errorf("--drop-chance: %v", err)
+	}
+
+	sweep, err := batch.RunSweep(g)
+	if err != nil {
+		return err
+	}
+
+	if *charts != "" {
+		if err := writeCharts(sweep, *charts); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Charts written to %s\n", *charts)
+	}
+
+	if *output == "" {
+		return sweep.Write(stdout, *format)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := sweep.Write(f, *format); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Table written to %s\n", *output)
+	return nil
+}
+
+// writeCharts writes one SVG file per chart metric into dir
+func writeCharts(sweep *batch.Sweep, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, cm := range batch.ChartMetrics {
+		f, err := os.Create(filepath.Join(dir, cm.Name+".svg"))
+		if err != nil {
+			return err
+		}
+		if err := sweep.WriteSVG(f, cm.Name); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseIntList parses comma-separated integers and inclusive ranges such as "1-3,5"
+func parseIntList(s string) ([]int, error) {
+	var values []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			lo, err := strconv.Atoi(from)
+			if err != nil {
+				return nil, err
+			}
+			hi, err := strconv.Atoi(to)
+			if err != nil {
+				return nil, err
+			}
+			if hi < lo {
+				return nil, fmt.Errorf("range %q is reversed", part)
+			}
+			for v := lo; v <= hi; v++ {
+				values = append(values, v)
+			}
+			continue
+		}
+
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// parseFlightRanges parses comma-separated "min-max" ranges; a single number means min=max
+func parseFlightRanges(s string) ([]batch.FlightRange, error) {
+	var ranges []batch.FlightRange
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		from, to, ok := strings.Cut(part, "-")
+		if !ok {
+			to = from
+		}
+		lo, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, err
+		}
+		hi, err := strconv.Atoi(to)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, batch.FlightRange{Min: lo, Max: hi})
+	}
+	return ranges, nil
+}
+
+// parseDurations parses comma-separated durations
+func parseDurations(s string) ([]time.Duration, error) {
+	var values []time.Duration
+	for _, part := range strings.Split(s, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, d)
+	}
+	return values, nil
+}
+
+// parseFloats parses comma-separated numbers
+func parseFloats(s string) ([]float64, error) {
+	var values []float64
+	for _, part := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"juggler/internal/juggler"
+	"juggler/internal/tui"
+)
+
+// runTUI shows an interactive dashboard in the terminal
+func runTUI(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("tui", stderr)
+	balls := fs.Int("balls", 3, "number of balls for new sessions")
+	minutes := fs.Int("minutes", 2, "juggling time in minutes for new sessions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *balls <= 0 || *minutes <= 0 {
+		return fmt.Errorf("balls and minutes must be positive")
+	}
+
+	restore, err := tui.MakeRaw(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+
+	return tui.NewDashboard(j, *balls, *minutes).Run(os.Stdin, stdout)
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"juggler/internal/siteswap"
+)
+
+// runValidate checks a siteswap pattern and prints its properties
+func runValidate(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("validate", stderr)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	p, err := siteswap.Validate(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("siteswap %q is not valid: %v", fs.Arg(0), err)
+	}
+
+	fmt.Fprintf(stdout, "Siteswap %s is valid\n", p)
+	fmt.Fprintf(stdout, "Balls: %d\n", p.Balls())
+	fmt.Fprintf(stdout, "Period: %d\n", p.Period())
+	return nil
+}
@@ -2,61 +2,164 @@ package config
 
 import (
 	"fmt"
-	"strconv"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"juggler/internal/i18n"
 )
 
 // Config holds the application configuration
 type Config struct {
-	WebPort int
+	WebPort         int
+	GRPCPort        int    // port for the gRPC API, 0 turns it off
+	LeaderboardFile string // where player profiles and runs are kept; empty keeps them in memory
+	AuthFile        string // API tokens and users with their roles; empty lets everyone do everything
+
+	// Each client may make RateLimit API requests per second on average, in
+	// bursts of up to RateBurst; a RateLimit of 0 turns limiting off
+	RateLimit    float64
+	RateBurst    int
+	MaxBodyBytes int64 // largest request body accepted
+
+	// HTTPS: serve with the certificate and key in TLSCert and TLSKey, or
+	// with a certificate generated at startup if TLSSelfSigned is set
+	TLSCert       string
+	TLSKey        string
+	TLSSelfSigned bool
+	HTTPPort      int           // plain HTTP port redirecting to HTTPS, 0 for none
+	HSTSMaxAge    time.Duration // how long browsers must use HTTPS only, 0 to not ask
+
+	// CORSOrigins may call the API from their pages and show /embed in a
+	// frame, e.g. "https://portal.example.com"; "*" allows any origin
+	CORSOrigins []string
+
+	// DevAssets serves the page templates and static files from this
+	// directory instead of the binary, for editing them without rebuilding
+	DevAssets string
+
+	// Lang is the language of logs and console output; pages and API
+	// messages follow each client's preference
+	Lang string
+
+	// Passing balls to a juggler in another process: listen for the peer
+	// on PeerListen or connect to it at PeerAddress
+	PeerListen  string
+	PeerAddress string
+	PeerName    string // how this juggler introduces itself to the peer
+	PassEvery   int    // every PassEvery-th throw goes to the peer
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
-		WebPort: 8080,
+		WebPort:      8080,
+		PeerName:     "juggler",
+		PassEvery:    2,
+		RateLimit:    10,
+		RateBurst:    20,
+		MaxBodyBytes: 1 << 20,
+		HSTSMaxAge:   365 * 24 * time.Hour,
+		Lang:         i18n.Russian,
 	}
 }
 
-// LoadFromArgs parses command line arguments and returns configuration
-func LoadFromArgs(args []string) (*Config, error) {
-	config := &Config{
-		WebPort: 8080, 
+// Validate validates the configuration
+func (c *Config) Validate() error {
+	if c.WebPort <= 0 || c.WebPort > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
 	}
-
-	// Optional first argument for custom port
-	if len(args) >= 2 {
-		port, err := strconv.Atoi(args[1])
-		if err != nil {
-			return nil, fmt.Errorf("wrong port format: %v", err)
+	if c.GRPCPort < 0 || c.GRPCPort > 65535 {
+		return fmt.Errorf("gRPC port must be between 0 and 65535")
+	}
+	if c.GRPCPort != 0 && c.GRPCPort == c.WebPort {
+		return fmt.Errorf("gRPC and web ports must differ")
+	}
+	if c.RateLimit < 0 || (c.RateLimit > 0 && c.RateBurst < 1) {
+		return fmt.Errorf("rate limit must not be negative and burst must be at least 1")
+	}
+	if c.MaxBodyBytes < 0 {
+		return fmt.Errorf("body limit must not be negative")
+	}
+	if err := c.validateTLS(); err != nil {
+		return err
+	}
+	if c.DevAssets != "" {
+		if info, err := os.Stat(c.DevAssets); err != nil || !info.IsDir() {
+			return fmt.Errorf("dev assets %q must be a directory", c.DevAssets)
+		}
+	}
+	if c.Lang != "" && !i18n.Supported(c.Lang) {
+		return fmt.Errorf("language must be one of %s", strings.Join(i18n.Languages(), ", "))
+	}
+	for _, origin := range c.CORSOrigins {
+		if !validOrigin(origin) {
+			return fmt.Errorf("CORS origin %q must be * or scheme://host[:port]", origin)
 		}
-		config.WebPort = port
+	}
+	if c.PeerListen != "" && c.PeerAddress != "" {
+		return fmt.Errorf("listen for a peer or connect to one, not both")
+	}
+	if (c.PeerListen != "" || c.PeerAddress != "") && c.PassEvery < 1 {
+		return fmt.Errorf("pass interval must be at least 1")
 	}
 
-	return config, nil
+	return nil
 }
 
-// PrintUsage prints the usage information
-func PrintUsage() {
-	fmt.Println("Usage: go run main.go [port]")
-	fmt.Println("Example: go run main.go")
-	fmt.Println("Example with port: go run main.go 8080")
-	fmt.Println()
-	fmt.Println("Parameters:")
-	fmt.Println("  port                - (optional) port for the web server (default 8080)")
-	fmt.Println()
-	fmt.Println("Juggling settings (number of balls, time) are set via the web interface.")
+// TLS reports whether the web server serves HTTPS
+func (c *Config) TLS() bool {
+	return c.TLSCert != "" || c.TLSSelfSigned
 }
 
-// Validate validates the configuration
-func (c *Config) Validate() error {
-	if c.WebPort <= 0 || c.WebPort > 65535 {
-		return fmt.Errorf("port must be between 1 and 65535")
+// validateTLS checks that the certificate and key are given together and
+// can be read, and that the redirect port is usable
+func (c *Config) validateTLS() error {
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return fmt.Errorf("TLS certificate and key must be given together")
+	}
+	if c.TLSCert != "" && c.TLSSelfSigned {
+		return fmt.Errorf("use a TLS certificate or a self-signed one, not both")
+	}
+	for _, path := range []string{c.TLSCert, c.TLSKey} {
+		if path == "" {
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("TLS file: %v", err)
+		}
+		f.Close()
 	}
 
+	if c.HTTPPort < 0 || c.HTTPPort > 65535 {
+		return fmt.Errorf("HTTP redirect port must be between 0 and 65535")
+	}
+	if c.HTTPPort != 0 && !c.TLS() {
+		return fmt.Errorf("HTTP redirect port needs TLS")
+	}
+	if c.HTTPPort != 0 && (c.HTTPPort == c.WebPort || c.HTTPPort == c.GRPCPort) {
+		return fmt.Errorf("HTTP redirect port must differ from the web and gRPC ports")
+	}
+	if c.HSTSMaxAge < 0 {
+		return fmt.Errorf("HSTS max age must not be negative")
+	}
 	return nil
 }
 
-// String returns a string representation of the configuration
+// validOrigin reports whether s is "*" or an origin as browsers send it
+func validOrigin(s string) bool {
+	if s == "*" {
+		return true
+	}
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		u.Path == "" && u.RawQuery == "" && u.Fragment == "" && u.User == nil
+}
+
+// String returns a string representation of the configuration in its
+// language, or the local language if it has none
 func (c *Config) String() string {
-	return fmt.Sprintf("Порт: %d", c.WebPort)
+	return i18n.T(c.Lang, "config.summary", c.WebPort)
 }
@@ -0,0 +1,86 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"juggler/internal/grpcapi/jugglerpb"
+	"juggler/internal/web"
+)
+
+// Client calls the service over a gRPC connection with the web API's
+// types; jugglerpb.NewJugglerClient gives the generated client instead
+type Client struct {
+	rpc jugglerpb.JugglerClient
+}
+
+// NewClient creates a client on a connection
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{rpc: jugglerpb.NewJugglerClient(cc)}
+}
+
+// Start starts a session
+func (c *Client) Start(ctx context.Context, req web.StartRequest) (*web.StartResponse, error) {
+	resp, err := c.rpc.Start(ctx, startRequestToPB(req))
+	if err != nil {
+		return nil, err
+	}
+	return startResponseFromPB(resp), nil
+}
+
+// Stop stops the running session
+func (c *Client) Stop(ctx context.Context) (*web.StatusResponse, error) {
+	resp, err := c.rpc.Stop(ctx, &jugglerpb.StopRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &web.StatusResponse{Status: resp.GetStatus(), Message: resp.GetMessage()}, nil
+}
+
+// GetStats returns the juggler's stats
+func (c *Client) GetStats(ctx context.Context) (*web.StatsResponse, error) {
+	resp, err := c.rpc.GetStats(ctx, &jugglerpb.StatsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return statsResponseFromPB(resp), nil
+}
+
+// TokenCredentials sends an API token with every call, for use with
+// grpc.WithPerRPCCredentials. The token travels in the clear unless the
+// connection uses TLS.
+type TokenCredentials string
+
+// GetRequestMetadata returns the authorization metadata
+func (t TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity allows the token on plain connections
+func (t TokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// EventStream receives the events of WatchEvents
+type EventStream struct {
+	stream grpc.ServerStreamingClient[jugglerpb.WatchEvent]
+}
+
+// Recv waits for the next event
+func (s *EventStream) Recv() (*WatchEvent, error) {
+	e, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return watchEventFromPB(e), nil
+}
+
+// WatchEvents follows the juggler's events until ctx is cancelled
+func (c *Client) WatchEvents(ctx context.Context, req WatchRequest) (*EventStream, error) {
+	stream, err := c.rpc.WatchEvents(ctx, &jugglerpb.WatchRequest{Since: int32(req.Since)})
+	if err != nil {
+		return nil, err
+	}
+	return &EventStream{stream: stream}, nil
+}
@@ -0,0 +1,332 @@
+package grpcapi
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"juggler/internal/grpcapi/jugglerpb"
+	"juggler/internal/juggler"
+	"juggler/internal/peer"
+	"juggler/internal/score"
+	"juggler/internal/web"
+)
+
+// The service speaks the messages of juggler.proto, the rest of the
+// program the web API's types; these functions convert between the two.
+
+// timestampToPB converts a time, leaving the zero time unset
+func timestampToPB(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timestampFromPB converts a timestamp, an unset one to the zero time
+func timestampFromPB(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func startRequestToPB(req web.StartRequest) *jugglerpb.StartRequest {
+	pb := &jugglerpb.StartRequest{
+		TotalBalls:  int32(req.TotalBalls),
+		TimeMinutes: int32(req.TimeMinutes),
+		Mode:        req.Mode,
+		Manual:      req.Manual,
+		Player:      req.Player,
+		Passing:     req.Passing,
+	}
+	for _, def := range req.Balls {
+		pb.Balls = append(pb.Balls, ballDefToPB(def))
+	}
+	if req.Performer != (juggler.PerformerOptions{}) {
+		pb.Performer = &jugglerpb.PerformerOptions{
+			Skill:    req.Performer.Skill,
+			Fatigue:  req.Performer.Fatigue,
+			Recovery: req.Performer.Recovery,
+		}
+	}
+	return pb
+}
+
+func startRequestFromPB(pb *jugglerpb.StartRequest) web.StartRequest {
+	req := web.StartRequest{
+		TotalBalls:  int(pb.GetTotalBalls()),
+		TimeMinutes: int(pb.GetTimeMinutes()),
+		Mode:        pb.GetMode(),
+		Manual:      pb.GetManual(),
+		Player:      pb.GetPlayer(),
+		Passing:     pb.GetPassing(),
+		Performer: juggler.PerformerOptions{
+			Skill:    pb.GetPerformer().GetSkill(),
+			Fatigue:  pb.GetPerformer().GetFatigue(),
+			Recovery: pb.GetPerformer().GetRecovery(),
+		},
+	}
+	for _, def := range pb.GetBalls() {
+		req.Balls = append(req.Balls, ballDefFromPB(def))
+	}
+	return req
+}
+
+func ballDefToPB(def juggler.BallDef) *jugglerpb.BallDef {
+	return &jugglerpb.BallDef{
+		Name:   def.Name,
+		Color:  def.Color,
+		Size:   int32(def.Size),
+		Weight: int32(def.Weight),
+		Type:   def.Type,
+	}
+}
+
+func ballDefFromPB(pb *jugglerpb.BallDef) juggler.BallDef {
+	return juggler.BallDef{
+		Name:   pb.GetName(),
+		Color:  pb.GetColor(),
+		Size:   int(pb.GetSize()),
+		Weight: int(pb.GetWeight()),
+		Type:   pb.GetType(),
+	}
+}
+
+func startResponseToPB(resp web.StartResponse) *jugglerpb.StartResponse {
+	pb := &jugglerpb.StartResponse{
+		Status:        resp.Status,
+		Message:       resp.Message,
+		QueuePosition: int32(resp.QueuePosition),
+	}
+	if info := resp.Session; info != nil {
+		pb.Session = &jugglerpb.SessionInfo{
+			Id:          int32(info.ID),
+			Player:      info.Player,
+			TotalBalls:  int32(info.TotalBalls),
+			TimeMinutes: int32(info.TimeMinutes),
+			StartTime:   timestampToPB(info.StartTime),
+		}
+	}
+	return pb
+}
+
+func startResponseFromPB(pb *jugglerpb.StartResponse) *web.StartResponse {
+	resp := &web.StartResponse{
+		Status:        pb.GetStatus(),
+		Message:       pb.GetMessage(),
+		QueuePosition: int(pb.GetQueuePosition()),
+	}
+	if info := pb.GetSession(); info != nil {
+		resp.Session = &web.SessionInfo{
+			ID:          int(info.GetId()),
+			Player:      info.GetPlayer(),
+			TotalBalls:  int(info.GetTotalBalls()),
+			TimeMinutes: int(info.GetTimeMinutes()),
+			StartTime:   timestampFromPB(info.GetStartTime()),
+		}
+	}
+	return resp
+}
+
+func statsResponseToPB(stats web.StatsResponse) *jugglerpb.StatsResponse {
+	pb := &jugglerpb.StatsResponse{
+		InHand:      int32(stats.InHand),
+		InAir:       int32(stats.InAir),
+		TimeElapsed: int32(stats.TimeElapsed),
+		IsFinished:  stats.IsFinished,
+		IsRunning:   stats.IsRunning,
+		TotalBalls:  int32(stats.TotalBalls),
+		TotalTime:   int32(stats.TotalTime),
+		Manual:      stats.Manual,
+		Passing:     stats.Passing,
+		Score: &jugglerpb.Score{
+			Points:           int32(stats.Score.Points),
+			Streak:           int32(stats.Score.Streak),
+			LongestStreak:    int32(stats.Score.LongestStreak),
+			Catches:          int32(stats.Score.Catches),
+			Drops:            int32(stats.Score.Drops),
+			CatchesPerMinute: stats.Score.CatchesPerMinute,
+		},
+	}
+	for _, a := range stats.Score.Achievements {
+		pb.Score.Achievements = append(pb.Score.Achievements, &jugglerpb.Achievement{
+			Id:          a.ID,
+			Name:        a.Name,
+			Description: a.Description,
+		})
+	}
+	for _, b := range stats.Balls {
+		pb.Balls = append(pb.Balls, &jugglerpb.Ball{
+			Id:         int32(b.ID),
+			Status:     b.Status,
+			FlightTime: int32(b.FlightTime),
+			Elapsed:    int32(b.Elapsed),
+			StartTime:  timestampToPB(b.StartTime),
+			Hand:       b.Hand,
+			Removing:   b.Removing,
+			Performer:  int32(b.Performer),
+			PassFrom:   int32(b.PassFrom),
+			Prop:       ballDefToPB(b.BallDef),
+		})
+	}
+	pb.Performer = performerStateToPB(stats.Performer)
+	for _, m := range stats.Troupe {
+		pb.Troupe = append(pb.Troupe, &jugglerpb.TroupeMember{
+			Performer:      int32(m.Performer),
+			InHand:         int32(m.InHand),
+			Incoming:       int32(m.Incoming),
+			Throws:         int32(m.Throws),
+			PassesMade:     int32(m.PassesMade),
+			PassesReceived: int32(m.PassesReceived),
+			Catches:        int32(m.Catches),
+			Drops:          int32(m.Drops),
+			State:          performerStateToPB(m.State),
+		})
+	}
+	if p := stats.Peer; p != nil {
+		pb.Peer = &jugglerpb.PeerStatus{
+			Name:          p.Name,
+			Connected:     p.Connected,
+			Peer:          p.Peer,
+			Address:       p.Address,
+			ClockOffsetMs: p.ClockOffset,
+			RttMs:         p.RTT,
+			InTransit:     int32(p.InTransit),
+			Passed:        int32(p.Passed),
+			Received:      int32(p.Received),
+			Lost:          int32(p.Lost),
+		}
+	}
+	return pb
+}
+
+func statsResponseFromPB(pb *jugglerpb.StatsResponse) *web.StatsResponse {
+	stats := &web.StatsResponse{
+		InHand:      int(pb.GetInHand()),
+		InAir:       int(pb.GetInAir()),
+		TimeElapsed: int(pb.GetTimeElapsed()),
+		IsFinished:  pb.GetIsFinished(),
+		IsRunning:   pb.GetIsRunning(),
+		TotalBalls:  int(pb.GetTotalBalls()),
+		TotalTime:   int(pb.GetTotalTime()),
+		Manual:      pb.GetManual(),
+		Passing:     pb.GetPassing(),
+		Score: score.Score{
+			Points:           int(pb.GetScore().GetPoints()),
+			Streak:           int(pb.GetScore().GetStreak()),
+			LongestStreak:    int(pb.GetScore().GetLongestStreak()),
+			Catches:          int(pb.GetScore().GetCatches()),
+			Drops:            int(pb.GetScore().GetDrops()),
+			CatchesPerMinute: pb.GetScore().GetCatchesPerMinute(),
+			Achievements:     []score.Achievement{},
+		},
+		Balls: []juggler.Ball{},
+	}
+	for _, a := range pb.GetScore().GetAchievements() {
+		stats.Score.Achievements = append(stats.Score.Achievements, score.Achievement{
+			ID:          a.GetId(),
+			Name:        a.GetName(),
+			Description: a.GetDescription(),
+		})
+	}
+	for _, b := range pb.GetBalls() {
+		stats.Balls = append(stats.Balls, juggler.Ball{
+			ID:         int(b.GetId()),
+			Status:     b.GetStatus(),
+			FlightTime: int(b.GetFlightTime()),
+			Elapsed:    int(b.GetElapsed()),
+			StartTime:  timestampFromPB(b.GetStartTime()),
+			Hand:       b.GetHand(),
+			Removing:   b.GetRemoving(),
+			Performer:  int(b.GetPerformer()),
+			PassFrom:   int(b.GetPassFrom()),
+			BallDef:    ballDefFromPB(b.GetProp()),
+		})
+	}
+	stats.Performer = performerStateFromPB(pb.GetPerformer())
+	for _, m := range pb.GetTroupe() {
+		stats.Troupe = append(stats.Troupe, juggler.TroupeMember{
+			Performer:      int(m.GetPerformer()),
+			InHand:         int(m.GetInHand()),
+			Incoming:       int(m.GetIncoming()),
+			Throws:         int(m.GetThrows()),
+			PassesMade:     int(m.GetPassesMade()),
+			PassesReceived: int(m.GetPassesReceived()),
+			Catches:        int(m.GetCatches()),
+			Drops:          int(m.GetDrops()),
+			State:          performerStateFromPB(m.GetState()),
+		})
+	}
+	if p := pb.GetPeer(); p != nil {
+		stats.Peer = &peer.Status{
+			Name:        p.GetName(),
+			Connected:   p.GetConnected(),
+			Peer:        p.GetPeer(),
+			Address:     p.GetAddress(),
+			ClockOffset: p.GetClockOffsetMs(),
+			RTT:         p.GetRttMs(),
+			InTransit:   int(p.GetInTransit()),
+			Passed:      int(p.GetPassed()),
+			Received:    int(p.GetReceived()),
+			Lost:        int(p.GetLost()),
+		}
+	}
+	return stats
+}
+
+// performerStateToPB converts the gauges of the performer model, nil when it is off
+func performerStateToPB(p *juggler.PerformerState) *jugglerpb.PerformerState {
+	if p == nil {
+		return nil
+	}
+	return &jugglerpb.PerformerState{
+		Skill:      p.Skill,
+		Stamina:    p.Stamina,
+		Form:       p.Form,
+		MissChance: p.MissChance,
+	}
+}
+
+// performerStateFromPB converts the gauges of the performer model, nil when it is off
+func performerStateFromPB(pb *jugglerpb.PerformerState) *juggler.PerformerState {
+	if pb == nil {
+		return nil
+	}
+	return &juggler.PerformerState{
+		Skill:      pb.GetSkill(),
+		Stamina:    pb.GetStamina(),
+		Form:       pb.GetForm(),
+		MissChance: pb.GetMissChance(),
+	}
+}
+
+func watchEventToPB(e WatchEvent) *jugglerpb.WatchEvent {
+	return &jugglerpb.WatchEvent{
+		Session:    int32(e.Session),
+		Time:       timestampToPB(e.Time),
+		BallId:     int32(e.BallID),
+		Type:       e.Type,
+		Hand:       e.Hand,
+		FlightTime: int32(e.FlightTime),
+		Performer:  int32(e.Performer),
+		To:         int32(e.To),
+		Remote:     e.Remote,
+	}
+}
+
+func watchEventFromPB(pb *jugglerpb.WatchEvent) *WatchEvent {
+	return &WatchEvent{
+		Session: int(pb.GetSession()),
+		Event: juggler.Event{
+			Time:       timestampFromPB(pb.GetTime()),
+			BallID:     int(pb.GetBallId()),
+			Type:       pb.GetType(),
+			Hand:       pb.GetHand(),
+			FlightTime: int(pb.GetFlightTime()),
+			Performer:  int(pb.GetPerformer()),
+			To:         int(pb.GetTo()),
+			Remote:     pb.GetRemote(),
+		},
+	}
+}
@@ -0,0 +1,205 @@
+// Package grpcapi serves the juggler over gRPC alongside the HTTP API.
+// The service, juggler.v1.Juggler, is defined in jugglerpb/juggler.proto
+// and mirrors the web server's operations, sharing its session control.
+// Server reflection is on, so generic tools such as grpcurl can call it.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"juggler/internal/auth"
+	"juggler/internal/grpcapi/jugglerpb"
+	"juggler/internal/i18n"
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+// ServiceName is the full name of the gRPC service
+const ServiceName = "juggler.v1.Juggler"
+
+// watchInterval is how often WatchEvents looks for new events
+const watchInterval = 100 * time.Millisecond
+
+// WatchRequest is the request of WatchEvents
+type WatchRequest struct {
+	// Since skips the current session's events before this index; 0
+	// replays the whole session before following new events
+	Since int `json:"since,omitempty"`
+}
+
+// WatchEvent is an event streamed by WatchEvents. Session changes when a
+// new session starts, and the stream then continues with its events.
+type WatchEvent struct {
+	Session int `json:"session"`
+	juggler.Event
+}
+
+// Service implements the gRPC service on top of a web server, so that
+// both APIs start, stop and queue the same sessions
+type Service struct {
+	jugglerpb.UnimplementedJugglerServer
+
+	web     *web.Server
+	juggler *juggler.Juggler
+	auth    *auth.Authenticator
+}
+
+// NewService creates the service for a web server and its juggler
+func NewService(s *web.Server, j *juggler.Juggler) *Service {
+	return &Service{web: s, juggler: j}
+}
+
+// SetAuth requires callers to send an API token as "authorization:
+// Bearer <token>" metadata, with the same roles as the HTTP API; nil, the
+// default, lets everyone call everything
+func (s *Service) SetAuth(a *auth.Authenticator) {
+	s.auth = a
+}
+
+// authorize checks that the caller's token grants the given role
+func (s *Service) authorize(ctx context.Context, role auth.Role) error {
+	if s.auth == nil {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			continue
+		}
+		id, ok := s.auth.CheckToken(token)
+		if !ok {
+			break
+		}
+		if !id.Role.Allows(role) {
+			return status.Error(codes.PermissionDenied, i18n.T(language(ctx), "error.requires_role", role))
+		}
+		return nil
+	}
+	return status.Error(codes.Unauthenticated, i18n.T(language(ctx), "error.auth_required"))
+}
+
+// language picks the language of a call's messages by its
+// "accept-language" metadata, as HTTP clients choose with Accept-Language,
+// falling back to the server's language
+func language(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("accept-language") {
+		if lang := i18n.Match(header); lang != "" {
+			return lang
+		}
+	}
+	return i18n.Local()
+}
+
+// Register registers the service with a gRPC server
+func (s *Service) Register(gs *grpc.Server) {
+	jugglerpb.RegisterJugglerServer(gs, s)
+}
+
+// NewServer creates a gRPC server serving the service and server reflection
+func NewServer(s *Service, opts ...grpc.ServerOption) *grpc.Server {
+	gs := grpc.NewServer(opts...)
+	s.Register(gs)
+	reflection.Register(gs)
+	return gs
+}
+
+// Start starts a session; see web.StartRequest for its modes
+func (s *Service) Start(ctx context.Context, req *jugglerpb.StartRequest) (*jugglerpb.StartResponse, error) {
+	if err := s.authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
+	lang := language(ctx)
+	resp, err := s.web.StartSession(startRequestFromPB(req), lang)
+	if err != nil {
+		return nil, toStatus(err, lang)
+	}
+	return startResponseToPB(resp), nil
+}
+
+// Stop stops the running session and drops queued ones
+func (s *Service) Stop(ctx context.Context, req *jugglerpb.StopRequest) (*jugglerpb.StatusResponse, error) {
+	if err := s.authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
+	s.web.StopSession()
+	return &jugglerpb.StatusResponse{Status: "stopped", Message: i18n.T(language(ctx), "status.stopped")}, nil
+}
+
+// GetStats returns the same snapshot as GET /stats
+func (s *Service) GetStats(ctx context.Context, req *jugglerpb.StatsRequest) (*jugglerpb.StatsResponse, error) {
+	if err := s.authorize(ctx, auth.Viewer); err != nil {
+		return nil, err
+	}
+	return statsResponseToPB(s.web.Stats()), nil
+}
+
+// WatchEvents streams the events of the current session and of every
+// session started after it, until the client goes away
+func (s *Service) WatchEvents(req *jugglerpb.WatchRequest, stream grpc.ServerStreamingServer[jugglerpb.WatchEvent]) error {
+	if err := s.authorize(stream.Context(), auth.Viewer); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	session, next := s.juggler.GetSession(), int(req.Since)
+	for {
+		current, events := s.juggler.GetEventsSince(next)
+		if current != session {
+			// A new session has started; follow it from its first event
+			session, next = current, 0
+			continue
+		}
+
+		for _, e := range events {
+			if err := stream.Send(watchEventToPB(WatchEvent{Session: session, Event: e})); err != nil {
+				return err
+			}
+		}
+		next += len(events)
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// toStatus maps a web error onto a gRPC status with its message in lang
+func toStatus(err error, lang string) error {
+	var invalid *web.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		st := status.New(codes.InvalidArgument, invalid.Message)
+		br := &errdetails.BadRequest{}
+		for _, d := range invalid.Details {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       d.Field,
+				Description: d.Message,
+			})
+		}
+		if detailed, err := st.WithDetails(br); err == nil {
+			st = detailed
+		}
+		return st.Err()
+	case errors.Is(err, web.ErrConflict):
+		return status.Error(codes.FailedPrecondition, i18n.T(lang, "error.already_running"))
+	default:
+		return status.Error(codes.Internal, i18n.Message(lang, err))
+	}
+}
@@ -0,0 +1,5 @@
+// Package jugglerpb holds the protobuf messages and gRPC stubs generated
+// from juggler.proto
+package jugglerpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative juggler.proto
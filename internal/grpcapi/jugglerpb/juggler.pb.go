@@ -0,0 +1,1640 @@
+// The juggler's gRPC API. It mirrors the HTTP API under /api/v1 and shares
+// its sessions; field names and meanings follow the HTTP API's JSON.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: juggler.proto
+
+package jugglerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StartRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TotalBalls  int32                  `protobuf:"varint,1,opt,name=total_balls,json=totalBalls,proto3" json:"total_balls,omitempty"`
+	TimeMinutes int32                  `protobuf:"varint,2,opt,name=time_minutes,json=timeMinutes,proto3" json:"time_minutes,omitempty"`
+	// What to do if a session is running: "reject" (default), "restart" or "queue"
+	Mode   string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Manual bool   `protobuf:"varint,4,opt,name=manual,proto3" json:"manual,omitempty"`
+	// Profile the session's run is recorded for
+	Player string `protobuf:"bytes,5,opt,name=player,proto3" json:"player,omitempty"`
+	// Props in order, cycling when there are fewer than balls
+	Balls []*BallDef `protobuf:"bytes,6,rep,name=balls,proto3" json:"balls,omitempty"`
+	// Skill and stamina model, on when skill is set
+	Performer *PerformerOptions `protobuf:"bytes,7,opt,name=performer,proto3" json:"performer,omitempty"`
+	// Passing pattern of a troupe, e.g. "p s | p s"
+	Passing       string `protobuf:"bytes,8,opt,name=passing,proto3" json:"passing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartRequest) Reset() {
+	*x = StartRequest{}
+	mi := &file_juggler_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRequest) ProtoMessage() {}
+
+func (x *StartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRequest.ProtoReflect.Descriptor instead.
+func (*StartRequest) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StartRequest) GetTotalBalls() int32 {
+	if x != nil {
+		return x.TotalBalls
+	}
+	return 0
+}
+
+func (x *StartRequest) GetTimeMinutes() int32 {
+	if x != nil {
+		return x.TimeMinutes
+	}
+	return 0
+}
+
+func (x *StartRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *StartRequest) GetManual() bool {
+	if x != nil {
+		return x.Manual
+	}
+	return false
+}
+
+func (x *StartRequest) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *StartRequest) GetBalls() []*BallDef {
+	if x != nil {
+		return x.Balls
+	}
+	return nil
+}
+
+func (x *StartRequest) GetPerformer() *PerformerOptions {
+	if x != nil {
+		return x.Performer
+	}
+	return nil
+}
+
+func (x *StartRequest) GetPassing() string {
+	if x != nil {
+		return x.Passing
+	}
+	return ""
+}
+
+type BallDef struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "#rrggbb"
+	Color string `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	// Centimetres, 0 for the default
+	Size int32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Grams, 0 for the default
+	Weight int32 `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	// "ball" (default), "club" or "ring"
+	Type          string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BallDef) Reset() {
+	*x = BallDef{}
+	mi := &file_juggler_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BallDef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BallDef) ProtoMessage() {}
+
+func (x *BallDef) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BallDef.ProtoReflect.Descriptor instead.
+func (*BallDef) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BallDef) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BallDef) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *BallDef) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BallDef) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *BallDef) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type PerformerOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skill         float64                `protobuf:"fixed64,1,opt,name=skill,proto3" json:"skill,omitempty"`
+	Fatigue       float64                `protobuf:"fixed64,2,opt,name=fatigue,proto3" json:"fatigue,omitempty"`
+	Recovery      float64                `protobuf:"fixed64,3,opt,name=recovery,proto3" json:"recovery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PerformerOptions) Reset() {
+	*x = PerformerOptions{}
+	mi := &file_juggler_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PerformerOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PerformerOptions) ProtoMessage() {}
+
+func (x *PerformerOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PerformerOptions.ProtoReflect.Descriptor instead.
+func (*PerformerOptions) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PerformerOptions) GetSkill() float64 {
+	if x != nil {
+		return x.Skill
+	}
+	return 0
+}
+
+func (x *PerformerOptions) GetFatigue() float64 {
+	if x != nil {
+		return x.Fatigue
+	}
+	return 0
+}
+
+func (x *PerformerOptions) GetRecovery() float64 {
+	if x != nil {
+		return x.Recovery
+	}
+	return 0
+}
+
+type StartResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "started", "restarted" or "queued"
+	Status  string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The started session, unset when queued
+	Session *SessionInfo `protobuf:"bytes,3,opt,name=session,proto3" json:"session,omitempty"`
+	// 1 for the next session to start
+	QueuePosition int32 `protobuf:"varint,4,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartResponse) Reset() {
+	*x = StartResponse{}
+	mi := &file_juggler_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartResponse) ProtoMessage() {}
+
+func (x *StartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartResponse.ProtoReflect.Descriptor instead.
+func (*StartResponse) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StartResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StartResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StartResponse) GetSession() *SessionInfo {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *StartResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+type SessionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Player        string                 `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	TotalBalls    int32                  `protobuf:"varint,3,opt,name=total_balls,json=totalBalls,proto3" json:"total_balls,omitempty"`
+	TimeMinutes   int32                  `protobuf:"varint,4,opt,name=time_minutes,json=timeMinutes,proto3" json:"time_minutes,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	mi := &file_juggler_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SessionInfo) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetPlayer() string {
+	if x != nil {
+		return x.Player
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetTotalBalls() int32 {
+	if x != nil {
+		return x.TotalBalls
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetTimeMinutes() int32 {
+	if x != nil {
+		return x.TimeMinutes
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+type StopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopRequest) Reset() {
+	*x = StopRequest{}
+	mi := &file_juggler_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRequest) ProtoMessage() {}
+
+func (x *StopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
+func (*StopRequest) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{5}
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_juggler_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_juggler_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{7}
+}
+
+type StatsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	InHand      int32                  `protobuf:"varint,1,opt,name=in_hand,json=inHand,proto3" json:"in_hand,omitempty"`
+	InAir       int32                  `protobuf:"varint,2,opt,name=in_air,json=inAir,proto3" json:"in_air,omitempty"`
+	Balls       []*Ball                `protobuf:"bytes,3,rep,name=balls,proto3" json:"balls,omitempty"`
+	TimeElapsed int32                  `protobuf:"varint,4,opt,name=time_elapsed,json=timeElapsed,proto3" json:"time_elapsed,omitempty"`
+	IsFinished  bool                   `protobuf:"varint,5,opt,name=is_finished,json=isFinished,proto3" json:"is_finished,omitempty"`
+	IsRunning   bool                   `protobuf:"varint,6,opt,name=is_running,json=isRunning,proto3" json:"is_running,omitempty"`
+	TotalBalls  int32                  `protobuf:"varint,7,opt,name=total_balls,json=totalBalls,proto3" json:"total_balls,omitempty"`
+	TotalTime   int32                  `protobuf:"varint,8,opt,name=total_time,json=totalTime,proto3" json:"total_time,omitempty"`
+	Manual      bool                   `protobuf:"varint,9,opt,name=manual,proto3" json:"manual,omitempty"`
+	Score       *Score                 `protobuf:"bytes,10,opt,name=score,proto3" json:"score,omitempty"`
+	// Live gauges of the performer model, if it is on
+	Performer *PerformerState `protobuf:"bytes,11,opt,name=performer,proto3" json:"performer,omitempty"`
+	Passing   string          `protobuf:"bytes,12,opt,name=passing,proto3" json:"passing,omitempty"`
+	Troupe    []*TroupeMember `protobuf:"bytes,13,rep,name=troupe,proto3" json:"troupe,omitempty"`
+	// Link to a juggler in another process, if configured
+	Peer          *PeerStatus `protobuf:"bytes,14,opt,name=peer,proto3" json:"peer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_juggler_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StatsResponse) GetInHand() int32 {
+	if x != nil {
+		return x.InHand
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetInAir() int32 {
+	if x != nil {
+		return x.InAir
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetBalls() []*Ball {
+	if x != nil {
+		return x.Balls
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetTimeElapsed() int32 {
+	if x != nil {
+		return x.TimeElapsed
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetIsFinished() bool {
+	if x != nil {
+		return x.IsFinished
+	}
+	return false
+}
+
+func (x *StatsResponse) GetIsRunning() bool {
+	if x != nil {
+		return x.IsRunning
+	}
+	return false
+}
+
+func (x *StatsResponse) GetTotalBalls() int32 {
+	if x != nil {
+		return x.TotalBalls
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetTotalTime() int32 {
+	if x != nil {
+		return x.TotalTime
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetManual() bool {
+	if x != nil {
+		return x.Manual
+	}
+	return false
+}
+
+func (x *StatsResponse) GetScore() *Score {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetPerformer() *PerformerState {
+	if x != nil {
+		return x.Performer
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetPassing() string {
+	if x != nil {
+		return x.Passing
+	}
+	return ""
+}
+
+func (x *StatsResponse) GetTroupe() []*TroupeMember {
+	if x != nil {
+		return x.Troupe
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetPeer() *PeerStatus {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+type Ball struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "in_hand", "in_flight" or "dropped"
+	Status     string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	FlightTime int32                  `protobuf:"varint,3,opt,name=flight_time,json=flightTime,proto3" json:"flight_time,omitempty"`
+	Elapsed    int32                  `protobuf:"varint,4,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	StartTime  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// "left" or "right"
+	Hand      string `protobuf:"bytes,6,opt,name=hand,proto3" json:"hand,omitempty"`
+	Removing  bool   `protobuf:"varint,7,opt,name=removing,proto3" json:"removing,omitempty"`
+	Performer int32  `protobuf:"varint,8,opt,name=performer,proto3" json:"performer,omitempty"`
+	PassFrom  int32  `protobuf:"varint,9,opt,name=pass_from,json=passFrom,proto3" json:"pass_from,omitempty"`
+	// What the ball is; the HTTP API inlines these fields
+	Prop          *BallDef `protobuf:"bytes,10,opt,name=prop,proto3" json:"prop,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ball) Reset() {
+	*x = Ball{}
+	mi := &file_juggler_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ball) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ball) ProtoMessage() {}
+
+func (x *Ball) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ball.ProtoReflect.Descriptor instead.
+func (*Ball) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Ball) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Ball) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Ball) GetFlightTime() int32 {
+	if x != nil {
+		return x.FlightTime
+	}
+	return 0
+}
+
+func (x *Ball) GetElapsed() int32 {
+	if x != nil {
+		return x.Elapsed
+	}
+	return 0
+}
+
+func (x *Ball) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Ball) GetHand() string {
+	if x != nil {
+		return x.Hand
+	}
+	return ""
+}
+
+func (x *Ball) GetRemoving() bool {
+	if x != nil {
+		return x.Removing
+	}
+	return false
+}
+
+func (x *Ball) GetPerformer() int32 {
+	if x != nil {
+		return x.Performer
+	}
+	return 0
+}
+
+func (x *Ball) GetPassFrom() int32 {
+	if x != nil {
+		return x.PassFrom
+	}
+	return 0
+}
+
+func (x *Ball) GetProp() *BallDef {
+	if x != nil {
+		return x.Prop
+	}
+	return nil
+}
+
+type Score struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Points           int32                  `protobuf:"varint,1,opt,name=points,proto3" json:"points,omitempty"`
+	Streak           int32                  `protobuf:"varint,2,opt,name=streak,proto3" json:"streak,omitempty"`
+	LongestStreak    int32                  `protobuf:"varint,3,opt,name=longest_streak,json=longestStreak,proto3" json:"longest_streak,omitempty"`
+	Catches          int32                  `protobuf:"varint,4,opt,name=catches,proto3" json:"catches,omitempty"`
+	Drops            int32                  `protobuf:"varint,5,opt,name=drops,proto3" json:"drops,omitempty"`
+	CatchesPerMinute float64                `protobuf:"fixed64,6,opt,name=catches_per_minute,json=catchesPerMinute,proto3" json:"catches_per_minute,omitempty"`
+	Achievements     []*Achievement         `protobuf:"bytes,7,rep,name=achievements,proto3" json:"achievements,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Score) Reset() {
+	*x = Score{}
+	mi := &file_juggler_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Score) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Score) ProtoMessage() {}
+
+func (x *Score) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Score.ProtoReflect.Descriptor instead.
+func (*Score) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Score) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *Score) GetStreak() int32 {
+	if x != nil {
+		return x.Streak
+	}
+	return 0
+}
+
+func (x *Score) GetLongestStreak() int32 {
+	if x != nil {
+		return x.LongestStreak
+	}
+	return 0
+}
+
+func (x *Score) GetCatches() int32 {
+	if x != nil {
+		return x.Catches
+	}
+	return 0
+}
+
+func (x *Score) GetDrops() int32 {
+	if x != nil {
+		return x.Drops
+	}
+	return 0
+}
+
+func (x *Score) GetCatchesPerMinute() float64 {
+	if x != nil {
+		return x.CatchesPerMinute
+	}
+	return 0
+}
+
+func (x *Score) GetAchievements() []*Achievement {
+	if x != nil {
+		return x.Achievements
+	}
+	return nil
+}
+
+type Achievement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Achievement) Reset() {
+	*x = Achievement{}
+	mi := &file_juggler_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Achievement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Achievement) ProtoMessage() {}
+
+func (x *Achievement) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Achievement.ProtoReflect.Descriptor instead.
+func (*Achievement) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Achievement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Achievement) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Achievement) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type PerformerState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skill         float64                `protobuf:"fixed64,1,opt,name=skill,proto3" json:"skill,omitempty"`
+	Stamina       float64                `protobuf:"fixed64,2,opt,name=stamina,proto3" json:"stamina,omitempty"`
+	Form          float64                `protobuf:"fixed64,3,opt,name=form,proto3" json:"form,omitempty"`
+	MissChance    float64                `protobuf:"fixed64,4,opt,name=miss_chance,json=missChance,proto3" json:"miss_chance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PerformerState) Reset() {
+	*x = PerformerState{}
+	mi := &file_juggler_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PerformerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PerformerState) ProtoMessage() {}
+
+func (x *PerformerState) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PerformerState.ProtoReflect.Descriptor instead.
+func (*PerformerState) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PerformerState) GetSkill() float64 {
+	if x != nil {
+		return x.Skill
+	}
+	return 0
+}
+
+func (x *PerformerState) GetStamina() float64 {
+	if x != nil {
+		return x.Stamina
+	}
+	return 0
+}
+
+func (x *PerformerState) GetForm() float64 {
+	if x != nil {
+		return x.Form
+	}
+	return 0
+}
+
+func (x *PerformerState) GetMissChance() float64 {
+	if x != nil {
+		return x.MissChance
+	}
+	return 0
+}
+
+type TroupeMember struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Performer      int32                  `protobuf:"varint,1,opt,name=performer,proto3" json:"performer,omitempty"`
+	InHand         int32                  `protobuf:"varint,2,opt,name=in_hand,json=inHand,proto3" json:"in_hand,omitempty"`
+	Incoming       int32                  `protobuf:"varint,3,opt,name=incoming,proto3" json:"incoming,omitempty"`
+	Throws         int32                  `protobuf:"varint,4,opt,name=throws,proto3" json:"throws,omitempty"`
+	PassesMade     int32                  `protobuf:"varint,5,opt,name=passes_made,json=passesMade,proto3" json:"passes_made,omitempty"`
+	PassesReceived int32                  `protobuf:"varint,6,opt,name=passes_received,json=passesReceived,proto3" json:"passes_received,omitempty"`
+	Catches        int32                  `protobuf:"varint,7,opt,name=catches,proto3" json:"catches,omitempty"`
+	Drops          int32                  `protobuf:"varint,8,opt,name=drops,proto3" json:"drops,omitempty"`
+	// Gauges of the performer model, if it is on
+	State         *PerformerState `protobuf:"bytes,9,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TroupeMember) Reset() {
+	*x = TroupeMember{}
+	mi := &file_juggler_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TroupeMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TroupeMember) ProtoMessage() {}
+
+func (x *TroupeMember) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TroupeMember.ProtoReflect.Descriptor instead.
+func (*TroupeMember) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TroupeMember) GetPerformer() int32 {
+	if x != nil {
+		return x.Performer
+	}
+	return 0
+}
+
+func (x *TroupeMember) GetInHand() int32 {
+	if x != nil {
+		return x.InHand
+	}
+	return 0
+}
+
+func (x *TroupeMember) GetIncoming() int32 {
+	if x != nil {
+		return x.Incoming
+	}
+	return 0
+}
+
+func (x *TroupeMember) GetThrows() int32 {
+	if x != nil {
+		return x.Throws
+	}
+	return 0
+}
+
+func (x *TroupeMember) GetPassesMade() int32 {
+	if x != nil {
+		return x.PassesMade
+	}
+	return 0
+}
+
+func (x *TroupeMember) GetPassesReceived() int32 {
+	if x != nil {
+		return x.PassesReceived
+	}
+	return 0
+}
+
+func (x *TroupeMember) GetCatches() int32 {
+	if x != nil {
+		return x.Catches
+	}
+	return 0
+}
+
+func (x *TroupeMember) GetDrops() int32 {
+	if x != nil {
+		return x.Drops
+	}
+	return 0
+}
+
+func (x *TroupeMember) GetState() *PerformerState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type PeerStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Connected     bool                   `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+	Peer          string                 `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	ClockOffsetMs float64                `protobuf:"fixed64,5,opt,name=clock_offset_ms,json=clockOffsetMs,proto3" json:"clock_offset_ms,omitempty"`
+	RttMs         float64                `protobuf:"fixed64,6,opt,name=rtt_ms,json=rttMs,proto3" json:"rtt_ms,omitempty"`
+	InTransit     int32                  `protobuf:"varint,7,opt,name=in_transit,json=inTransit,proto3" json:"in_transit,omitempty"`
+	Passed        int32                  `protobuf:"varint,8,opt,name=passed,proto3" json:"passed,omitempty"`
+	Received      int32                  `protobuf:"varint,9,opt,name=received,proto3" json:"received,omitempty"`
+	Lost          int32                  `protobuf:"varint,10,opt,name=lost,proto3" json:"lost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerStatus) Reset() {
+	*x = PeerStatus{}
+	mi := &file_juggler_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStatus) ProtoMessage() {}
+
+func (x *PeerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStatus.ProtoReflect.Descriptor instead.
+func (*PeerStatus) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PeerStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *PeerStatus) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetClockOffsetMs() float64 {
+	if x != nil {
+		return x.ClockOffsetMs
+	}
+	return 0
+}
+
+func (x *PeerStatus) GetRttMs() float64 {
+	if x != nil {
+		return x.RttMs
+	}
+	return 0
+}
+
+func (x *PeerStatus) GetInTransit() int32 {
+	if x != nil {
+		return x.InTransit
+	}
+	return 0
+}
+
+func (x *PeerStatus) GetPassed() int32 {
+	if x != nil {
+		return x.Passed
+	}
+	return 0
+}
+
+func (x *PeerStatus) GetReceived() int32 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *PeerStatus) GetLost() int32 {
+	if x != nil {
+		return x.Lost
+	}
+	return 0
+}
+
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Skips the current session's events before this index; 0 replays the
+	// whole session before following new events
+	Since         int32 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_juggler_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchRequest) GetSince() int32 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type WatchEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Changes when a new session starts; the stream then continues with its events
+	Session int32                  `protobuf:"varint,1,opt,name=session,proto3" json:"session,omitempty"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	BallId  int32                  `protobuf:"varint,3,opt,name=ball_id,json=ballId,proto3" json:"ball_id,omitempty"`
+	// "throw", "catch" or "drop"
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Hand          string `protobuf:"bytes,5,opt,name=hand,proto3" json:"hand,omitempty"`
+	FlightTime    int32  `protobuf:"varint,6,opt,name=flight_time,json=flightTime,proto3" json:"flight_time,omitempty"`
+	Performer     int32  `protobuf:"varint,7,opt,name=performer,proto3" json:"performer,omitempty"`
+	To            int32  `protobuf:"varint,8,opt,name=to,proto3" json:"to,omitempty"`
+	Remote        bool   `protobuf:"varint,9,opt,name=remote,proto3" json:"remote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	mi := &file_juggler_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_juggler_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEvent.ProtoReflect.Descriptor instead.
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return file_juggler_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WatchEvent) GetSession() int32 {
+	if x != nil {
+		return x.Session
+	}
+	return 0
+}
+
+func (x *WatchEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetBallId() int32 {
+	if x != nil {
+		return x.BallId
+	}
+	return 0
+}
+
+func (x *WatchEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetHand() string {
+	if x != nil {
+		return x.Hand
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetFlightTime() int32 {
+	if x != nil {
+		return x.FlightTime
+	}
+	return 0
+}
+
+func (x *WatchEvent) GetPerformer() int32 {
+	if x != nil {
+		return x.Performer
+	}
+	return 0
+}
+
+func (x *WatchEvent) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *WatchEvent) GetRemote() bool {
+	if x != nil {
+		return x.Remote
+	}
+	return false
+}
+
+var File_juggler_proto protoreflect.FileDescriptor
+
+const file_juggler_proto_rawDesc = "" +
+	"\n" +
+	"\rjuggler.proto\x12\n" +
+	"juggler.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x97\x02\n" +
+	"\fStartRequest\x12\x1f\n" +
+	"\vtotal_balls\x18\x01 \x01(\x05R\n" +
+	"totalBalls\x12!\n" +
+	"\ftime_minutes\x18\x02 \x01(\x05R\vtimeMinutes\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06manual\x18\x04 \x01(\bR\x06manual\x12\x16\n" +
+	"\x06player\x18\x05 \x01(\tR\x06player\x12)\n" +
+	"\x05balls\x18\x06 \x03(\v2\x13.juggler.v1.BallDefR\x05balls\x12:\n" +
+	"\tperformer\x18\a \x01(\v2\x1c.juggler.v1.PerformerOptionsR\tperformer\x12\x18\n" +
+	"\apassing\x18\b \x01(\tR\apassing\"s\n" +
+	"\aBallDef\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x05R\x06weight\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\"^\n" +
+	"\x10PerformerOptions\x12\x14\n" +
+	"\x05skill\x18\x01 \x01(\x01R\x05skill\x12\x18\n" +
+	"\afatigue\x18\x02 \x01(\x01R\afatigue\x12\x1a\n" +
+	"\brecovery\x18\x03 \x01(\x01R\brecovery\"\x9b\x01\n" +
+	"\rStartResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x121\n" +
+	"\asession\x18\x03 \x01(\v2\x17.juggler.v1.SessionInfoR\asession\x12%\n" +
+	"\x0equeue_position\x18\x04 \x01(\x05R\rqueuePosition\"\xb4\x01\n" +
+	"\vSessionInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06player\x18\x02 \x01(\tR\x06player\x12\x1f\n" +
+	"\vtotal_balls\x18\x03 \x01(\x05R\n" +
+	"totalBalls\x12!\n" +
+	"\ftime_minutes\x18\x04 \x01(\x05R\vtimeMinutes\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\"\r\n" +
+	"\vStopRequest\"B\n" +
+	"\x0eStatusResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x0e\n" +
+	"\fStatsRequest\"\xfd\x03\n" +
+	"\rStatsResponse\x12\x17\n" +
+	"\ain_hand\x18\x01 \x01(\x05R\x06inHand\x12\x15\n" +
+	"\x06in_air\x18\x02 \x01(\x05R\x05inAir\x12&\n" +
+	"\x05balls\x18\x03 \x03(\v2\x10.juggler.v1.BallR\x05balls\x12!\n" +
+	"\ftime_elapsed\x18\x04 \x01(\x05R\vtimeElapsed\x12\x1f\n" +
+	"\vis_finished\x18\x05 \x01(\bR\n" +
+	"isFinished\x12\x1d\n" +
+	"\n" +
+	"is_running\x18\x06 \x01(\bR\tisRunning\x12\x1f\n" +
+	"\vtotal_balls\x18\a \x01(\x05R\n" +
+	"totalBalls\x12\x1d\n" +
+	"\n" +
+	"total_time\x18\b \x01(\x05R\ttotalTime\x12\x16\n" +
+	"\x06manual\x18\t \x01(\bR\x06manual\x12'\n" +
+	"\x05score\x18\n" +
+	" \x01(\v2\x11.juggler.v1.ScoreR\x05score\x128\n" +
+	"\tperformer\x18\v \x01(\v2\x1a.juggler.v1.PerformerStateR\tperformer\x12\x18\n" +
+	"\apassing\x18\f \x01(\tR\apassing\x120\n" +
+	"\x06troupe\x18\r \x03(\v2\x18.juggler.v1.TroupeMemberR\x06troupe\x12*\n" +
+	"\x04peer\x18\x0e \x01(\v2\x16.juggler.v1.PeerStatusR\x04peer\"\xb8\x02\n" +
+	"\x04Ball\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vflight_time\x18\x03 \x01(\x05R\n" +
+	"flightTime\x12\x18\n" +
+	"\aelapsed\x18\x04 \x01(\x05R\aelapsed\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12\x12\n" +
+	"\x04hand\x18\x06 \x01(\tR\x04hand\x12\x1a\n" +
+	"\bremoving\x18\a \x01(\bR\bremoving\x12\x1c\n" +
+	"\tperformer\x18\b \x01(\x05R\tperformer\x12\x1b\n" +
+	"\tpass_from\x18\t \x01(\x05R\bpassFrom\x12'\n" +
+	"\x04prop\x18\n" +
+	" \x01(\v2\x13.juggler.v1.BallDefR\x04prop\"\xf9\x01\n" +
+	"\x05Score\x12\x16\n" +
+	"\x06points\x18\x01 \x01(\x05R\x06points\x12\x16\n" +
+	"\x06streak\x18\x02 \x01(\x05R\x06streak\x12%\n" +
+	"\x0elongest_streak\x18\x03 \x01(\x05R\rlongestStreak\x12\x18\n" +
+	"\acatches\x18\x04 \x01(\x05R\acatches\x12\x14\n" +
+	"\x05drops\x18\x05 \x01(\x05R\x05drops\x12,\n" +
+	"\x12catches_per_minute\x18\x06 \x01(\x01R\x10catchesPerMinute\x12;\n" +
+	"\fachievements\x18\a \x03(\v2\x17.juggler.v1.AchievementR\fachievements\"S\n" +
+	"\vAchievement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"u\n" +
+	"\x0ePerformerState\x12\x14\n" +
+	"\x05skill\x18\x01 \x01(\x01R\x05skill\x12\x18\n" +
+	"\astamina\x18\x02 \x01(\x01R\astamina\x12\x12\n" +
+	"\x04form\x18\x03 \x01(\x01R\x04form\x12\x1f\n" +
+	"\vmiss_chance\x18\x04 \x01(\x01R\n" +
+	"missChance\"\xa5\x02\n" +
+	"\fTroupeMember\x12\x1c\n" +
+	"\tperformer\x18\x01 \x01(\x05R\tperformer\x12\x17\n" +
+	"\ain_hand\x18\x02 \x01(\x05R\x06inHand\x12\x1a\n" +
+	"\bincoming\x18\x03 \x01(\x05R\bincoming\x12\x16\n" +
+	"\x06throws\x18\x04 \x01(\x05R\x06throws\x12\x1f\n" +
+	"\vpasses_made\x18\x05 \x01(\x05R\n" +
+	"passesMade\x12'\n" +
+	"\x0fpasses_received\x18\x06 \x01(\x05R\x0epassesReceived\x12\x18\n" +
+	"\acatches\x18\a \x01(\x05R\acatches\x12\x14\n" +
+	"\x05drops\x18\b \x01(\x05R\x05drops\x120\n" +
+	"\x05state\x18\t \x01(\v2\x1a.juggler.v1.PerformerStateR\x05state\"\x92\x02\n" +
+	"\n" +
+	"PeerStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tconnected\x18\x02 \x01(\bR\tconnected\x12\x12\n" +
+	"\x04peer\x18\x03 \x01(\tR\x04peer\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12&\n" +
+	"\x0fclock_offset_ms\x18\x05 \x01(\x01R\rclockOffsetMs\x12\x15\n" +
+	"\x06rtt_ms\x18\x06 \x01(\x01R\x05rttMs\x12\x1d\n" +
+	"\n" +
+	"in_transit\x18\a \x01(\x05R\tinTransit\x12\x16\n" +
+	"\x06passed\x18\b \x01(\x05R\x06passed\x12\x1a\n" +
+	"\breceived\x18\t \x01(\x05R\breceived\x12\x12\n" +
+	"\x04lost\x18\n" +
+	" \x01(\x05R\x04lost\"$\n" +
+	"\fWatchRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x05R\x05since\"\xfe\x01\n" +
+	"\n" +
+	"WatchEvent\x12\x18\n" +
+	"\asession\x18\x01 \x01(\x05R\asession\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x17\n" +
+	"\aball_id\x18\x03 \x01(\x05R\x06ballId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x12\n" +
+	"\x04hand\x18\x05 \x01(\tR\x04hand\x12\x1f\n" +
+	"\vflight_time\x18\x06 \x01(\x05R\n" +
+	"flightTime\x12\x1c\n" +
+	"\tperformer\x18\a \x01(\x05R\tperformer\x12\x0e\n" +
+	"\x02to\x18\b \x01(\x05R\x02to\x12\x16\n" +
+	"\x06remote\x18\t \x01(\bR\x06remote2\x88\x02\n" +
+	"\aJuggler\x12<\n" +
+	"\x05Start\x12\x18.juggler.v1.StartRequest\x1a\x19.juggler.v1.StartResponse\x12;\n" +
+	"\x04Stop\x12\x17.juggler.v1.StopRequest\x1a\x1a.juggler.v1.StatusResponse\x12?\n" +
+	"\bGetStats\x12\x18.juggler.v1.StatsRequest\x1a\x19.juggler.v1.StatsResponse\x12A\n" +
+	"\vWatchEvents\x12\x18.juggler.v1.WatchRequest\x1a\x16.juggler.v1.WatchEvent0\x01B$Z\"juggler/internal/grpcapi/jugglerpbb\x06proto3"
+
+var (
+	file_juggler_proto_rawDescOnce sync.Once
+	file_juggler_proto_rawDescData []byte
+)
+
+func file_juggler_proto_rawDescGZIP() []byte {
+	file_juggler_proto_rawDescOnce.Do(func() {
+		file_juggler_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_juggler_proto_rawDesc), len(file_juggler_proto_rawDesc)))
+	})
+	return file_juggler_proto_rawDescData
+}
+
+var file_juggler_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_juggler_proto_goTypes = []any{
+	(*StartRequest)(nil),          // 0: juggler.v1.StartRequest
+	(*BallDef)(nil),               // 1: juggler.v1.BallDef
+	(*PerformerOptions)(nil),      // 2: juggler.v1.PerformerOptions
+	(*StartResponse)(nil),         // 3: juggler.v1.StartResponse
+	(*SessionInfo)(nil),           // 4: juggler.v1.SessionInfo
+	(*StopRequest)(nil),           // 5: juggler.v1.StopRequest
+	(*StatusResponse)(nil),        // 6: juggler.v1.StatusResponse
+	(*StatsRequest)(nil),          // 7: juggler.v1.StatsRequest
+	(*StatsResponse)(nil),         // 8: juggler.v1.StatsResponse
+	(*Ball)(nil),                  // 9: juggler.v1.Ball
+	(*Score)(nil),                 // 10: juggler.v1.Score
+	(*Achievement)(nil),           // 11: juggler.v1.Achievement
+	(*PerformerState)(nil),        // 12: juggler.v1.PerformerState
+	(*TroupeMember)(nil),          // 13: juggler.v1.TroupeMember
+	(*PeerStatus)(nil),            // 14: juggler.v1.PeerStatus
+	(*WatchRequest)(nil),          // 15: juggler.v1.WatchRequest
+	(*WatchEvent)(nil),            // 16: juggler.v1.WatchEvent
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+}
+var file_juggler_proto_depIdxs = []int32{
+	1,  // 0: juggler.v1.StartRequest.balls:type_name -> juggler.v1.BallDef
+	2,  // 1: juggler.v1.StartRequest.performer:type_name -> juggler.v1.PerformerOptions
+	4,  // 2: juggler.v1.StartResponse.session:type_name -> juggler.v1.SessionInfo
+	17, // 3: juggler.v1.SessionInfo.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 4: juggler.v1.StatsResponse.balls:type_name -> juggler.v1.Ball
+	10, // 5: juggler.v1.StatsResponse.score:type_name -> juggler.v1.Score
+	12, // 6: juggler.v1.StatsResponse.performer:type_name -> juggler.v1.PerformerState
+	13, // 7: juggler.v1.StatsResponse.troupe:type_name -> juggler.v1.TroupeMember
+	14, // 8: juggler.v1.StatsResponse.peer:type_name -> juggler.v1.PeerStatus
+	17, // 9: juggler.v1.Ball.start_time:type_name -> google.protobuf.Timestamp
+	1,  // 10: juggler.v1.Ball.prop:type_name -> juggler.v1.BallDef
+	11, // 11: juggler.v1.Score.achievements:type_name -> juggler.v1.Achievement
+	12, // 12: juggler.v1.TroupeMember.state:type_name -> juggler.v1.PerformerState
+	17, // 13: juggler.v1.WatchEvent.time:type_name -> google.protobuf.Timestamp
+	0,  // 14: juggler.v1.Juggler.Start:input_type -> juggler.v1.StartRequest
+	5,  // 15: juggler.v1.Juggler.Stop:input_type -> juggler.v1.StopRequest
+	7,  // 16: juggler.v1.Juggler.GetStats:input_type -> juggler.v1.StatsRequest
+	15, // 17: juggler.v1.Juggler.WatchEvents:input_type -> juggler.v1.WatchRequest
+	3,  // 18: juggler.v1.Juggler.Start:output_type -> juggler.v1.StartResponse
+	6,  // 19: juggler.v1.Juggler.Stop:output_type -> juggler.v1.StatusResponse
+	8,  // 20: juggler.v1.Juggler.GetStats:output_type -> juggler.v1.StatsResponse
+	16, // 21: juggler.v1.Juggler.WatchEvents:output_type -> juggler.v1.WatchEvent
+	18, // [18:22] is the sub-list for method output_type
+	14, // [14:18] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_juggler_proto_init() }
+func file_juggler_proto_init() {
+	if File_juggler_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_juggler_proto_rawDesc), len(file_juggler_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_juggler_proto_goTypes,
+		DependencyIndexes: file_juggler_proto_depIdxs,
+		MessageInfos:      file_juggler_proto_msgTypes,
+	}.Build()
+	File_juggler_proto = out.File
+	file_juggler_proto_goTypes = nil
+	file_juggler_proto_depIdxs = nil
+}
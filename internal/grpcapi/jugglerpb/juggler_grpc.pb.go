@@ -0,0 +1,252 @@
+// The juggler's gRPC API. It mirrors the HTTP API under /api/v1 and shares
+// its sessions; field names and meanings follow the HTTP API's JSON.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: juggler.proto
+
+package jugglerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Juggler_Start_FullMethodName       = "/juggler.v1.Juggler/Start"
+	Juggler_Stop_FullMethodName        = "/juggler.v1.Juggler/Stop"
+	Juggler_GetStats_FullMethodName    = "/juggler.v1.Juggler/GetStats"
+	Juggler_WatchEvents_FullMethodName = "/juggler.v1.Juggler/WatchEvents"
+)
+
+// JugglerClient is the client API for Juggler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type JugglerClient interface {
+	// Start starts a session, like POST /api/v1/start without Idempotency-Key
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	// Stop stops the running session and drops queued ones
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// GetStats returns the same snapshot as GET /api/v1/stats
+	GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	// WatchEvents streams the events of the current session and of every
+	// session started after it
+	WatchEvents(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error)
+}
+
+type jugglerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJugglerClient(cc grpc.ClientConnInterface) JugglerClient {
+	return &jugglerClient{cc}
+}
+
+func (c *jugglerClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartResponse)
+	err := c.cc.Invoke(ctx, Juggler_Start_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jugglerClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, Juggler_Stop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jugglerClient) GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, Juggler_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jugglerClient) WatchEvents(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Juggler_ServiceDesc.Streams[0], Juggler_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, WatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Juggler_WatchEventsClient = grpc.ServerStreamingClient[WatchEvent]
+
+// JugglerServer is the server API for Juggler service.
+// All implementations must embed UnimplementedJugglerServer
+// for forward compatibility.
+type JugglerServer interface {
+	// Start starts a session, like POST /api/v1/start without Idempotency-Key
+	Start(context.Context, *StartRequest) (*StartResponse, error)
+	// Stop stops the running session and drops queued ones
+	Stop(context.Context, *StopRequest) (*StatusResponse, error)
+	// GetStats returns the same snapshot as GET /api/v1/stats
+	GetStats(context.Context, *StatsRequest) (*StatsResponse, error)
+	// WatchEvents streams the events of the current session and of every
+	// session started after it
+	WatchEvents(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error
+	mustEmbedUnimplementedJugglerServer()
+}
+
+// UnimplementedJugglerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJugglerServer struct{}
+
+func (UnimplementedJugglerServer) Start(context.Context, *StartRequest) (*StartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (UnimplementedJugglerServer) Stop(context.Context, *StopRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (UnimplementedJugglerServer) GetStats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedJugglerServer) WatchEvents(*WatchRequest, grpc.ServerStreamingServer[WatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedJugglerServer) mustEmbedUnimplementedJugglerServer() {}
+func (UnimplementedJugglerServer) testEmbeddedByValue()                 {}
+
+// UnsafeJugglerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JugglerServer will
+// result in compilation errors.
+type UnsafeJugglerServer interface {
+	mustEmbedUnimplementedJugglerServer()
+}
+
+func RegisterJugglerServer(s grpc.ServiceRegistrar, srv JugglerServer) {
+	// If the following call pancis, it indicates UnimplementedJugglerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Juggler_ServiceDesc, srv)
+}
+
+func _Juggler_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JugglerServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Juggler_Start_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JugglerServer).Start(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Juggler_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JugglerServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Juggler_Stop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JugglerServer).Stop(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Juggler_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JugglerServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Juggler_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JugglerServer).GetStats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Juggler_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JugglerServer).WatchEvents(m, &grpc.GenericServerStream[WatchRequest, WatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Juggler_WatchEventsServer = grpc.ServerStreamingServer[WatchEvent]
+
+// Juggler_ServiceDesc is the grpc.ServiceDesc for Juggler service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Juggler_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "juggler.v1.Juggler",
+	HandlerType: (*JugglerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Start",
+			Handler:    _Juggler_Start_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _Juggler_Stop_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Juggler_GetStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Juggler_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "juggler.proto",
+}
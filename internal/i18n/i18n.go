@@ -0,0 +1,208 @@
+// Package i18n holds the message catalogs of the UI, API messages and logs
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Languages with a catalog
+const (
+	Russian = "ru"
+	English = "en"
+)
+
+// locales holds one catalog per language, named after it, e.g. ru.json
+//
+//go:embed locales/*.json
+var locales embed.FS
+
+// catalogs maps languages to their messages by key
+var catalogs = loadCatalogs()
+
+// local is the language of the server's own output, such as logs
+var local atomic.Value
+
+func init() {
+	local.Store(Russian)
+}
+
+// loadCatalogs reads the embedded catalogs
+func loadCatalogs() map[string]map[string]string {
+	files, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	catalogs := make(map[string]map[string]string)
+	for _, f := range files {
+		data, err := locales.ReadFile("locales/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("catalog %s: %v", f.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(f.Name(), path.Ext(f.Name()))] = messages
+	}
+	return catalogs
+}
+
+// Languages returns the languages with a catalog, sorted
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Supported reports whether lang has a catalog
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Keys returns the message keys of a language's catalog, sorted
+func Keys(lang string) []string {
+	keys := make([]string, 0, len(catalogs[lang]))
+	for key := range catalogs[lang] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Catalog returns the messages of a language whose keys start with prefix
+func Catalog(lang, prefix string) map[string]string {
+	messages := make(map[string]string)
+	for key, msg := range catalogs[lang] {
+		if strings.HasPrefix(key, prefix) {
+			messages[key] = msg
+		}
+	}
+	return messages
+}
+
+// T returns the message with the key in lang, formatted with args as by
+// fmt.Sprintf. Unsupported languages get the local language; unknown keys
+// are returned as they are.
+func T(lang, key string, args ...any) string {
+	messages, ok := catalogs[lang]
+	if !ok {
+		messages = catalogs[Local()]
+	}
+	msg, ok := messages[key]
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// L returns a message in the local language, for the server's own output
+func L(key string, args ...any) string {
+	return T(Local(), key, args...)
+}
+
+// Local returns the language of the server's own output, Russian by default
+func Local() string {
+	return local.Load().(string)
+}
+
+// SetLocal sets the language of the server's own output
+func SetLocal(lang string) error {
+	if !Supported(lang) {
+		return fmt.Errorf("unsupported language %q, want one of %s", lang, strings.Join(Languages(), ", "))
+	}
+	local.Store(lang)
+	return nil
+}
+
+// Match picks the supported language a client prefers most by its
+// Accept-Language header, e.g. "en-US,en;q=0.9,ru;q=0.8", or returns ""
+// if it accepts none of them
+func Match(acceptLanguage string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if lang == "*" {
+			lang = Local()
+		}
+		if q > bestQ && Supported(lang) {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// Error is an error whose message is a catalog entry, so that it can be
+// shown in each reader's language. Error returns the message in English.
+type Error struct {
+	Key  string
+	Args []any // arguments of the message; errors among them are translated too
+	Err  error // wrapped for errors.Is, without being part of the message
+}
+
+// NewError returns an error with the message of the key, formatted with args
+func NewError(key string, args ...any) *Error {
+	return &Error{Key: key, Args: args}
+}
+
+func (e *Error) Error() string {
+	return e.Message(English)
+}
+
+// Message returns the error's message in lang
+func (e *Error) Message(lang string) string {
+	args := make([]any, len(e.Args))
+	for i, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			arg = Message(lang, err)
+		}
+		args[i] = arg
+	}
+	return T(lang, e.Key, args...)
+}
+
+// Unwrap returns Err and the errors among the arguments
+func (e *Error) Unwrap() []error {
+	var errs []error
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Message returns an error's message in lang: translated for an *Error,
+// and as it is for any other error
+func Message(lang string, err error) string {
+	if e, ok := err.(*Error); ok {
+		return e.Message(lang)
+	}
+	return err.Error()
+}
@@ -0,0 +1,52 @@
+package juggler
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the juggler what time it is
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the wall clock
+type realClock struct{}
+
+// Now returns the current wall clock time
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// VirtualClock is a manually advanced clock that lets Simulate run
+// a session much faster than real time
+type VirtualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewVirtualClock creates a virtual clock showing start
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+// Now returns the current virtual time
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t
+func (c *VirtualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
@@ -0,0 +1,156 @@
+package juggler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Event records a single change of a ball's state
+type Event struct {
+	Time       time.Time `json:"time"`
+	BallID     int       `json:"ball_id"`
+	Type       string    `json:"type"`                  // "throw", "catch", "drop"
+	Hand       string    `json:"hand,omitempty"`        // throwing hand for throws, receiving hand otherwise
+	FlightTime int       `json:"flight_time,omitempty"` // seconds, set for throws
+	Performer  int       `json:"performer,omitempty"`   // troupe performer throwing, catching or dropping
+	To         int       `json:"to,omitempty"`          // performer a pass goes to, set for passes
+	Remote     bool      `json:"remote,omitempty"`      // the ball was passed to or from a peer process
+}
+
+// Summary holds aggregate figures for a recorded session
+type Summary struct {
+	Balls         int
+	Throws        int
+	Catches       int
+	Drops         int
+	LongestStreak int // most consecutive catches without a drop
+	Duration      time.Duration
+	AirTime       time.Duration // total flight time of all throws
+	Utilization   float64       // share of ball time spent in the air, 0..1
+	AvgInAir      float64       // time-weighted mean number of balls in the air
+	IdleHandTime  time.Duration // total time caught balls waited in hand before being thrown again
+}
+
+// Summarize computes a summary of the given events
+func Summarize(events []Event) Summary {
+	var s Summary
+	balls := make(map[int]bool)
+	caughtAt := make(map[int]time.Time)
+	streak := 0
+
+	for _, e := range events {
+		balls[e.BallID] = true
+		switch e.Type {
+		case "throw":
+			s.Throws++
+			s.AirTime += time.Duration(e.FlightTime) * time.Second
+			if t, ok := caughtAt[e.BallID]; ok {
+				s.IdleHandTime += e.Time.Sub(t)
+				delete(caughtAt, e.BallID)
+			}
+		case "catch":
+			s.Catches++
+			caughtAt[e.BallID] = e.Time
+			streak++
+			if streak > s.LongestStreak {
+				s.LongestStreak = streak
+			}
+		case "drop":
+			s.Drops++
+			streak = 0
+		}
+	}
+
+	s.Balls = len(balls)
+	if len(events) > 0 {
+		s.Duration = events[len(events)-1].Time.Sub(events[0].Time)
+	}
+	if s.Balls > 0 && s.Duration > 0 {
+		s.Utilization = s.AirTime.Seconds() / (float64(s.Balls) * s.Duration.Seconds())
+		s.AvgInAir = s.AirTime.Seconds() / s.Duration.Seconds()
+	}
+
+	return s
+}
+
+// BallSummary holds aggregate figures for one ball of a session
+type BallSummary struct {
+	BallID  int           `json:"ball_id"`
+	Throws  int           `json:"throws"`
+	Catches int           `json:"catches"`
+	Drops   int           `json:"drops"`
+	AirTime time.Duration `json:"air_time"`
+}
+
+// SummarizeBalls computes a summary per ball, ordered by ball ID
+func SummarizeBalls(events []Event) []BallSummary {
+	byID := make(map[int]*BallSummary)
+	ids := make([]int, 0)
+
+	for _, e := range events {
+		b, ok := byID[e.BallID]
+		if !ok {
+			b = &BallSummary{BallID: e.BallID}
+			byID[e.BallID] = b
+			ids = append(ids, e.BallID)
+		}
+
+		switch e.Type {
+		case "throw":
+			b.Throws++
+			b.AirTime += time.Duration(e.FlightTime) * time.Second
+		case "catch":
+			b.Catches++
+		case "drop":
+			b.Drops++
+		}
+	}
+
+	sort.Ints(ids)
+	summaries := make([]BallSummary, 0, len(ids))
+	for _, id := range ids {
+		summaries = append(summaries, *byID[id])
+	}
+	return summaries
+}
+
+// WriteEvents writes events to w as JSON lines, one event per line
+func WriteEvents(w io.Writer, events []Event) error {
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadEvents reads events written by WriteEvents
+func ReadEvents(r io.Reader) ([]Event, error) {
+	events := make([]Event, 0)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		events = append(events, e)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
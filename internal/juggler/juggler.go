@@ -2,12 +2,18 @@ package juggler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
+
+	"juggler/internal/i18n"
+	"juggler/internal/passing"
 )
 
 // Ball represents a juggling ball
@@ -17,6 +23,112 @@ type Ball struct {
 	FlightTime int       `json:"flight_time"` // seconds
 	Elapsed    int       `json:"elapsed"`     // seconds elapsed in flight
 	StartTime  time.Time `json:"start_time"`
+	Hand       string    `json:"hand"`                // "left", "right": hand holding the ball, or catching it while in flight
+	Removing   bool      `json:"removing,omitempty"`  // set by RemoveBall while the ball is in flight
+	Performer  int       `json:"performer,omitempty"` // troupe performer holding or catching the ball, from 1
+	PassFrom   int       `json:"pass_from,omitempty"` // performer who passed the ball while it is in flight
+	BallDef              // what the ball is; affects its flight times and drops
+
+	heldSince     time.Time // when the ball was last caught or put in hand
+	remoteID      int       // ID at the peer that passed the ball, until it lands
+	remoteSession int       // the peer's session when it passed the ball
+}
+
+// ErrUnknownBall is returned for ball IDs that are not part of the session
+var ErrUnknownBall = errors.New("unknown ball")
+
+// startingHand spreads balls between hands at the start of a session
+func startingHand(ballID int) string {
+	if ballID%2 == 1 {
+		return "right"
+	}
+	return "left"
+}
+
+// newBall creates a ball in hand. In a troupe balls are dealt to the
+// performers in turn. The caller must hold the lock.
+func (j *Juggler) newBall(id int, heldSince time.Time) *Ball {
+	ball := &Ball{
+		ID:        id,
+		Status:    "in_hand",
+		Hand:      startingHand(id),
+		BallDef:   j.ballDef(id),
+		heldSince: heldSince,
+	}
+	if j.pattern != nil {
+		n := j.pattern.Performers()
+		ball.Performer = (id-1)%n + 1
+		ball.Hand = startingHand((id-1)/n + 1)
+	}
+	return ball
+}
+
+// otherHand returns the opposite hand; in a cascade every throw crosses over
+func otherHand(hand string) string {
+	if hand == "right" {
+		return "left"
+	}
+	return "right"
+}
+
+// Options holds the tunable parameters of the simulation
+type Options struct {
+	MinFlightTime int           `json:"min_flight_time"` // seconds
+	MaxFlightTime int           `json:"max_flight_time"` // seconds
+	ThrowInterval time.Duration `json:"throw_interval"`  // how often balls in hand are thrown
+	DropChance    float64       `json:"drop_chance"`     // probability that a landing ball is dropped
+
+	// Manual mode: the engine never throws; balls are thrown with Throw.
+	// A ball landing in a full hand or held longer than HoldTime is dropped.
+	Manual       bool          `json:"manual"`
+	HoldTime     time.Duration `json:"hold_time"`     // 0 means 3 seconds
+	HandCapacity int           `json:"hand_capacity"` // balls one hand can hold, 0 means 2
+
+	// Performer models the juggler's skill and stamina; off by default
+	Performer PerformerOptions `json:"performer,omitzero"`
+
+	// Passing turns the session into a troupe passing balls in the given
+	// pattern, see passing.Parse
+	Passing string `json:"passing,omitempty"`
+
+	// PassEvery sends every PassEvery-th throw to the peer set with
+	// SetPeer; 0 never passes
+	PassEvery int `json:"pass_every,omitempty"`
+}
+
+// DefaultOptions returns the options used unless SetOptions is called
+func DefaultOptions() Options {
+	return Options{
+		MinFlightTime: 5,
+		MaxFlightTime: 10,
+		ThrowInterval: 500 * time.Millisecond,
+		DropChance:    0,
+	}
+}
+
+// Validate validates the options
+func (o Options) Validate() error {
+	if o.MinFlightTime < 1 || o.MaxFlightTime < o.MinFlightTime {
+		return i18n.NewError("juggler.flight_range")
+	}
+	if o.ThrowInterval <= 0 {
+		return i18n.NewError("juggler.throw_interval")
+	}
+	if o.DropChance < 0 || o.DropChance > 1 {
+		return i18n.NewError("juggler.drop_chance")
+	}
+	if o.HoldTime < 0 || o.HandCapacity < 0 || o.PassEvery < 0 {
+		return i18n.NewError("juggler.negative_options")
+	}
+	if err := o.Performer.Validate(); err != nil {
+		return i18n.NewError("juggler.performer", err)
+	}
+	if o.Passing != "" {
+		if _, err := passing.Parse(o.Passing); err != nil {
+			return i18n.NewError("juggler.passing", err)
+		}
+	}
+	return nil
 }
 
 // Juggler manages the juggling process
@@ -28,8 +140,26 @@ type Juggler struct {
 	mu           sync.RWMutex
 	totalBalls   int
 	jugglingTime time.Duration
-	startTime    time.Time
+	startTime    time.Time // start moved on by every pause, the base of elapsed
+	startedAt    time.Time // when the session really started
 	finished     bool
+	session      int             // incremented by Reset; goroutines of older sessions exit
+	started      bool            // whether Start has run for the current session
+	flights      *errgroup.Group // flights of the started session, used by Throw
+	paused       bool
+	pausedAt     time.Time
+	events       []Event
+	done         chan struct{}
+	out          io.Writer
+	options      Options
+	clock        Clock
+	rng          *rand.Rand
+	ballDefs     []BallDef        // see SetBallDefs
+	stamina      map[int]stamina  // stamina of each performer who has thrown, see PerformerOptions
+	pattern      *passing.Pattern // parsed Options.Passing, nil for a solo juggler
+	throwCounts  map[int]int      // throws made by each troupe performer this session
+	peer         PeerLink         // juggler in another process, see SetPeer
+	peerThrows   int              // throws counted towards Options.PassEvery
 }
 
 // NewJuggler creates a new juggler
@@ -43,15 +173,20 @@ func NewJuggler(totalBalls int, jugglingTimeMinutes int) *Juggler {
 		jugglingTime: time.Duration(jugglingTimeMinutes) * time.Minute,
 		startTime:    time.Now(),
 		finished:     true, // Start as finished/not running
+		events:       make([]Event, 0),
+		done:         make(chan struct{}),
+		out:          os.Stdout,
+		options:      DefaultOptions(),
+		clock:        realClock{},
+		rng:          rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
+	j.startedAt = j.startTime
+	j.resetStamina()
+	j.throwCounts = make(map[int]int)
 
 	if totalBalls > 0 {
 		for i := 0; i < totalBalls; i++ {
-			ball := &Ball{
-				ID:     j.nextBallID,
-				Status: "in_hand",
-			}
-			j.balls[j.nextBallID] = ball
+			j.balls[j.nextBallID] = j.newBall(j.nextBallID, j.startTime)
 			j.ballsInHand = append(j.ballsInHand, j.nextBallID)
 			j.nextBallID++
 		}
@@ -81,30 +216,88 @@ func (j *Juggler) ThrowBall(ctx context.Context, eg *errgroup.Group) bool {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
+	if len(j.ballsInHand) == 0 {
+		return false
+	}
+	ballID := j.ballsInHand[0]
+	j.throw()
+	if _, ok := j.balls[ballID]; !ok {
+		return true // passed to the peer
+	}
+
+	session := j.session
+	eg.Go(func() error {
+		return j.flyBall(ctx, session, ballID)
+	})
+
+	return true
+}
+
+// throw moves the first ball in hand into the air and reports whether
+// there was one. The caller must hold the lock.
+func (j *Juggler) throw() bool {
 	if len(j.ballsInHand) == 0 {
 		return false
 	}
 
 	ballID := j.ballsInHand[0]
-	j.ballsInHand = j.ballsInHand[1:]
+	j.throwBall(ballID, j.randomFlightTime(ballID))
+	return true
+}
+
+// randomFlightTime picks a flight time for the given ball within the
+// range of its prop. The caller must hold the lock.
+func (j *Juggler) randomFlightTime(ballID int) int {
+	ball := j.balls[ballID]
+	min, max := ball.flightRange(j.options.MinFlightTime, j.options.MaxFlightTime)
+	if j.options.Performer.enabled() {
+		return j.aimedFlightTime(ball.Performer, min, max)
+	}
+	return j.rng.Intn(max-min+1) + min
+}
+
+// throwBall moves the given ball from hand into the air for flightTime
+// seconds. The caller must hold the lock.
+func (j *Juggler) throwBall(ballID, flightTime int) {
+	for i, id := range j.ballsInHand {
+		if id == ballID {
+			j.ballsInHand = append(j.ballsInHand[:i], j.ballsInHand[i+1:]...)
+			break
+		}
+	}
+
+	if j.passesToPeer() {
+		j.passToPeer(ballID, flightTime)
+		return
+	}
 
 	j.ballsInAir = append(j.ballsInAir, ballID)
 
 	ball := j.balls[ballID]
 	ball.Status = "in_flight"
-	ball.FlightTime = rand.Intn(6) + 5
+	ball.FlightTime = flightTime
 	ball.Elapsed = 0
-	ball.StartTime = time.Now()
+	ball.StartTime = j.clock.Now()
 
-	eg.Go(func() error {
-		return j.flyBall(ctx, ballID)
+	thrower, to := j.route(ball)
+	if j.options.Performer.enabled() {
+		j.tire(thrower)
+	}
+	j.events = append(j.events, Event{
+		Time:       ball.StartTime,
+		BallID:     ballID,
+		Type:       "throw",
+		Hand:       ball.Hand,
+		FlightTime: ball.FlightTime,
+		Performer:  thrower,
+		To:         to,
 	})
-
-	return true
+	ball.Hand = otherHand(ball.Hand)
 }
 
-// flyBall simulates a ball flying in the air
-func (j *Juggler) flyBall(ctx context.Context, ballID int) error {
+// flyBall simulates a ball flying in the air. It returns early once the
+// juggler has been reset, since the ball belongs to an older session.
+func (j *Juggler) flyBall(ctx context.Context, session, ballID int) error {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
@@ -114,13 +307,24 @@ func (j *Juggler) flyBall(ctx context.Context, ballID int) error {
 			return ctx.Err()
 		case <-ticker.C:
 			j.mu.Lock()
-			ball := j.balls[ballID]
-			ball.Elapsed++
+			if j.session != session {
+				j.mu.Unlock()
+				return nil
+			}
+			if j.paused {
+				j.mu.Unlock()
+				continue
+			}
 
-			fmt.Printf("Ball %d: %d/%d seconds\n", ballID, ball.Elapsed, ball.FlightTime)
+			ball, ok := j.balls[ballID]
+			if !ok {
+				// A pass from a peer that has disconnected
+				j.mu.Unlock()
+				return nil
+			}
+			fmt.Fprintf(j.out, "Ball %d: %d/%d seconds\n", ballID, ball.Elapsed+1, ball.FlightTime)
 
-			if ball.Elapsed >= ball.FlightTime {
-				j.catchBall(ballID)
+			if j.advanceBall(ballID) {
 				j.mu.Unlock()
 				return nil
 			}
@@ -129,25 +333,110 @@ func (j *Juggler) flyBall(ctx context.Context, ballID int) error {
 	}
 }
 
-// catchBall catches a ball and puts it back in hand
-func (j *Juggler) catchBall(ballID int) {
+// advanceBall moves a ball in the air one second further and lands it
+// when its flight is over. It reports whether the ball has landed.
+// The caller must hold the lock.
+func (j *Juggler) advanceBall(ballID int) bool {
+	ball := j.balls[ballID]
+	ball.Elapsed++
+
+	if ball.Elapsed < ball.FlightTime {
+		return false
+	}
+
+	j.removeFromAir(ballID)
+	ball.PassFrom = 0
+	if j.options.Manual && j.handLoad(ball.Performer, ball.Hand) >= j.options.handCapacity() {
+		j.dropBall(ballID)
+	} else if chance := j.landingDropChance(ball); chance > 0 && j.rng.Float64() < chance {
+		j.dropBall(ballID)
+	} else {
+		j.catchBall(ballID)
+	}
+	j.landedFromPeer(ball)
+	if ball.Removing {
+		j.removeBall(ballID)
+	}
+	return true
+}
+
+// landingDropChance returns the chance that a landing ball is dropped,
+// either because of the prop or because the performer misses it.
+// The caller must hold the lock.
+func (j *Juggler) landingDropChance(ball *Ball) float64 {
+	chance := 0.0
+	if j.options.DropChance > 0 {
+		chance = ball.dropChance(j.options.DropChance)
+	}
+	return 1 - (1-chance)*(1-j.missChance(ball.Performer))
+}
+
+// removeFromAir removes a ball from the list of balls in the air
+func (j *Juggler) removeFromAir(ballID int) {
 	for i, id := range j.ballsInAir {
 		if id == ballID {
 			j.ballsInAir = append(j.ballsInAir[:i], j.ballsInAir[i+1:]...)
 			break
 		}
 	}
+}
 
+// catchBall catches a ball and puts it back in hand
+func (j *Juggler) catchBall(ballID int) {
 	j.ballsInHand = append(j.ballsInHand, ballID)
 
 	ball := j.balls[ballID]
 	ball.Status = "in_hand"
 	ball.Elapsed = 0
+	ball.heldSince = j.clock.Now()
+
+	j.events = append(j.events, Event{
+		Time:      j.clock.Now(),
+		BallID:    ballID,
+		Type:      "catch",
+		Hand:      ball.Hand,
+		Performer: ball.Performer,
+		Remote:    ball.remoteID != 0,
+	})
+}
+
+// dropBall marks a ball as dropped; it stays on the floor for the rest of the session
+func (j *Juggler) dropBall(ballID int) {
+	ball := j.balls[ballID]
+	ball.Status = "dropped"
+	ball.Elapsed = 0
+
+	j.events = append(j.events, Event{
+		Time:      j.clock.Now(),
+		BallID:    ballID,
+		Type:      "drop",
+		Hand:      ball.Hand,
+		Performer: ball.Performer,
+		Remote:    ball.remoteID != 0,
+	})
 }
 
 // IsJugglingTimeOver checks if juggling time is over
 func (j *Juggler) IsJugglingTimeOver() bool {
-	return time.Since(j.startTime) >= j.jugglingTime
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.elapsed() >= j.jugglingTime
+}
+
+// elapsed returns the juggling time spent so far, excluding pauses.
+// The caller must hold the lock.
+func (j *Juggler) elapsed() time.Duration {
+	if j.paused {
+		return j.pausedAt.Sub(j.startTime)
+	}
+	return j.clock.Now().Sub(j.startTime)
+}
+
+// GetElapsed returns the juggling time spent so far, excluding pauses
+func (j *Juggler) GetElapsed() time.Duration {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.elapsed()
 }
 
 // AllBallsInHand checks if all balls are in hand (none in air)
@@ -159,14 +448,19 @@ func (j *Juggler) AllBallsInHand() bool {
 
 // PrintStats prints current juggling statistics
 func (j *Juggler) PrintStats() {
+	j.WriteStats(os.Stdout)
+}
+
+// WriteStats writes current juggling statistics to w
+func (j *Juggler) WriteStats(w io.Writer) {
 	j.mu.RLock()
 	defer j.mu.RUnlock()
 
-	fmt.Printf("\n=== Juggling State ===\n")
-	fmt.Printf("Elapsed Time: %.0f seconds\n", time.Since(j.startTime).Seconds())
-	fmt.Printf("Balls in Hand: %d\n", len(j.ballsInHand))
-	fmt.Printf("Balls in Air: %d\n", len(j.ballsInAir))
-	fmt.Printf("Ball Details:\n")
+	fmt.Fprintf(w, "\n=== Juggling State ===\n")
+	fmt.Fprintf(w, "Elapsed Time: %.0f seconds\n", j.elapsed().Seconds())
+	fmt.Fprintf(w, "Balls in Hand: %d\n", len(j.ballsInHand))
+	fmt.Fprintf(w, "Balls in Air: %d\n", len(j.ballsInAir))
+	fmt.Fprintf(w, "Ball Details:\n")
 
 	for _, ball := range j.balls {
 		status := ball.Status
@@ -176,9 +470,9 @@ func (j *Juggler) PrintStats() {
 		case "in_hand":
 			status = "in hand"
 		}
-		fmt.Printf("  Ball %d: %s\n", ball.ID, status)
+		fmt.Fprintf(w, "  Ball %d: %s\n", ball.ID, status)
 	}
-	fmt.Printf("========================\n\n")
+	fmt.Fprintf(w, "========================\n\n")
 }
 
 // SetFinished marks juggling as finished
@@ -195,11 +489,11 @@ func (j *Juggler) IsFinished() bool {
 	return j.finished
 }
 
-// GetStartTime returns the start time of juggling
+// GetStartTime returns when the session started, unaffected by pauses
 func (j *Juggler) GetStartTime() time.Time {
 	j.mu.RLock()
 	defer j.mu.RUnlock()
-	return j.startTime
+	return j.startedAt
 }
 
 // GetTotalBalls returns the total number of balls
@@ -227,27 +521,96 @@ func (j *Juggler) Reset(totalBalls int, jugglingTimeMinutes int) {
 	j.nextBallID = 1
 	j.totalBalls = totalBalls
 	j.jugglingTime = time.Duration(jugglingTimeMinutes) * time.Minute
-	j.startTime = time.Now()
+	j.startTime = j.clock.Now()
+	j.startedAt = j.startTime
 	j.finished = false
+	j.session++
+	j.started = false
+	j.flights = nil
+	j.paused = false
+	j.events = make([]Event, 0)
+	j.done = make(chan struct{})
+	j.resetStamina()
+	j.throwCounts = make(map[int]int)
+	j.peerThrows = 0
 
 	for i := 0; i < totalBalls; i++ {
-		ball := &Ball{
-			ID:     j.nextBallID,
-			Status: "in_hand",
-		}
-		j.balls[j.nextBallID] = ball
+		j.balls[j.nextBallID] = j.newBall(j.nextBallID, j.startTime)
 		j.ballsInHand = append(j.ballsInHand, j.nextBallID)
 		j.nextBallID++
 	}
 }
 
-// Start starts the juggling simulation
+// GetEvents returns a copy of the events recorded since the last reset
+func (j *Juggler) GetEvents() []Event {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	events := make([]Event, len(j.events))
+	copy(events, j.events)
+	return events
+}
+
+// GetEventsSince returns the current session and a copy of its events
+// from index from on, so that a watcher can pick up where it left off
+func (j *Juggler) GetEventsSince(from int) (session int, events []Event) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	from = max(from, 0)
+	if from < len(j.events) {
+		events = make([]Event, len(j.events)-from)
+		copy(events, j.events[from:])
+	}
+	return j.session, events
+}
+
+// Done returns a channel that is closed once the current run has ended
+// and every ball thrown during it has landed
+func (j *Juggler) Done() <-chan struct{} {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.done
+}
+
+// GetSession returns the identity of the current session, which changes on every Reset
+func (j *Juggler) GetSession() int {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.session
+}
+
+// isSession reports whether session is still the current one
+func (j *Juggler) isSession(session int) bool {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.session == session
+}
+
+// Start starts the juggling simulation. It does nothing if the current
+// session has already been started; call Reset first to begin a new one.
+// Goroutines of a previous session stop as soon as Reset is called.
 func (j *Juggler) Start() {
+	j.mu.Lock()
+	if j.started {
+		j.mu.Unlock()
+		return
+	}
+	j.started = true
+	session := j.session
+	done := j.done
+	eg := &errgroup.Group{}
+	j.flights = eg
+	j.mu.Unlock()
+
 	go func() {
 		ctx := context.Background()
-		eg := &errgroup.Group{}
+		defer func() {
+			eg.Wait()
+			close(done)
+		}()
 
-		throwTicker := time.NewTicker(time.Millisecond * 500) 
+		throwTicker := time.NewTicker(j.GetOptions().ThrowInterval)
 		defer throwTicker.Stop()
 
 		for {
@@ -255,11 +618,25 @@ func (j *Juggler) Start() {
 			case <-ctx.Done():
 				return
 			case <-throwTicker.C:
+				if !j.isSession(session) {
+					return
+				}
 				if j.IsFinished() || j.IsJugglingTimeOver() {
 					j.SetFinished()
 					return
 				}
 
+				if j.IsPaused() {
+					continue
+				}
+
+				if j.GetOptions().Manual {
+					j.mu.Lock()
+					j.dropHeldBalls()
+					j.mu.Unlock()
+					continue
+				}
+
 				thrownCount := 0
 				for {
 					if j.ThrowBall(ctx, eg) {
@@ -270,7 +647,7 @@ func (j *Juggler) Start() {
 				}
 
 				if thrownCount > 0 {
-					fmt.Printf("Threw %d ball(s)! Time: %.0f seconds\n", thrownCount, time.Since(j.GetStartTime()).Seconds())
+					fmt.Fprintf(j.output(), "Threw %d ball(s)! Time: %.0f seconds\n", thrownCount, j.GetElapsed().Seconds())
 				}
 			}
 		}
@@ -281,7 +658,7 @@ func (j *Juggler) Start() {
 func (j *Juggler) IsRunning() bool {
 	j.mu.RLock()
 	defer j.mu.RUnlock()
-	return !j.finished && time.Since(j.startTime) < j.jugglingTime
+	return !j.finished && j.elapsed() < j.jugglingTime
 }
 
 // Stop stops the juggling process
@@ -289,4 +666,145 @@ func (j *Juggler) Stop() {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 	j.finished = true
+	j.paused = false
+}
+
+// Pause freezes the session: no balls are thrown, balls in the air stop
+// advancing and the session clock stands still until Resume is called
+func (j *Juggler) Pause() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.paused || j.finished {
+		return
+	}
+	j.paused = true
+	j.pausedAt = j.clock.Now()
+}
+
+// Resume continues a paused session
+func (j *Juggler) Resume() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.paused {
+		return
+	}
+	paused := j.clock.Now().Sub(j.pausedAt)
+	j.startTime = j.startTime.Add(paused)
+	for _, id := range j.ballsInHand {
+		j.balls[id].heldSince = j.balls[id].heldSince.Add(paused)
+	}
+	j.paused = false
+}
+
+// IsPaused checks if the session is paused
+func (j *Juggler) IsPaused() bool {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.paused
+}
+
+// AddBall puts a new ball in hand and returns its ID.
+// During a running session the ball is thrown on the next throw tick.
+func (j *Juggler) AddBall() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	ball := j.newBall(j.nextBallID, j.clock.Now())
+	j.balls[ball.ID] = ball
+	j.ballsInHand = append(j.ballsInHand, ball.ID)
+	j.nextBallID++
+	j.totalBalls++
+
+	return ball.ID
+}
+
+// RemoveBall takes a ball out of the session. A ball in hand or on the
+// floor is removed at once; a ball in flight is marked and removed when it
+// lands. It reports whether the ball was removed immediately. The IDs of
+// the remaining balls do not change.
+func (j *Juggler) RemoveBall(id int) (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	ball, ok := j.balls[id]
+	if !ok {
+		return false, ErrUnknownBall
+	}
+
+	if ball.Status == "in_flight" {
+		ball.Removing = true
+		return false, nil
+	}
+
+	j.removeBall(id)
+	return true, nil
+}
+
+// removeBall deletes a ball that is not in the air. The caller must hold the lock.
+func (j *Juggler) removeBall(id int) {
+	for i, handID := range j.ballsInHand {
+		if handID == id {
+			j.ballsInHand = append(j.ballsInHand[:i], j.ballsInHand[i+1:]...)
+			break
+		}
+	}
+	delete(j.balls, id)
+	j.totalBalls--
+}
+
+// SetOutput sets the destination of the progress messages printed while
+// juggling. It defaults to standard output.
+func (j *Juggler) SetOutput(w io.Writer) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.out = w
+}
+
+// output returns the destination of progress messages
+func (j *Juggler) output() io.Writer {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.out
+}
+
+// SetOptions changes the simulation parameters used by subsequent throws
+func (j *Juggler) SetOptions(o Options) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	var pattern *passing.Pattern
+	if o.Passing != "" {
+		pattern, _ = passing.Parse(o.Passing)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.options = o
+	j.pattern = pattern
+	return nil
+}
+
+// GetOptions returns the simulation parameters
+func (j *Juggler) GetOptions() Options {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.options
+}
+
+// SetClock replaces the wall clock, typically with a VirtualClock for Simulate
+func (j *Juggler) SetClock(c Clock) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.clock = c
+}
+
+// SetSeed seeds the random source used for flight times and drops,
+// making a session reproducible
+func (j *Juggler) SetSeed(seed int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.rng = rand.New(rand.NewSource(seed))
 }
@@ -0,0 +1,119 @@
+package juggler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"juggler/internal/i18n"
+)
+
+// Errors returned by Throw
+var (
+	ErrNotRunning = errors.New("no session is running")
+	ErrNotManual  = errors.New("session is not in manual mode")
+	ErrNotInHand  = errors.New("ball is not in hand")
+	ErrNoBall     = errors.New("no ball in hand")
+	ErrPaused     = errors.New("session is paused")
+	ErrHeight     = errors.New("height out of range")
+)
+
+// handCapacity returns how many balls one hand can hold
+func (o Options) handCapacity() int {
+	if o.HandCapacity == 0 {
+		return 2
+	}
+	return o.HandCapacity
+}
+
+// holdTime returns how long a ball may stay in hand in manual mode
+func (o Options) holdTime() time.Duration {
+	if o.HoldTime == 0 {
+		return 3 * time.Second
+	}
+	return o.HoldTime
+}
+
+// Throw throws a ball in manual mode. ballID 0 throws the ball that has
+// been held the longest; height is the flight time in seconds, within
+// the range of the ball's prop, with 0 picking one at random as the
+// engine does.
+func (j *Juggler) Throw(ballID, height int) (Ball, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.options.Manual {
+		return Ball{}, ErrNotManual
+	}
+	if j.flights == nil || j.finished || j.elapsed() >= j.jugglingTime {
+		return Ball{}, ErrNotRunning
+	}
+	if j.paused {
+		return Ball{}, ErrPaused
+	}
+	if ballID == 0 {
+		if len(j.ballsInHand) == 0 {
+			return Ball{}, ErrNoBall
+		}
+		ballID = j.ballsInHand[0]
+	}
+	ball, ok := j.balls[ballID]
+	if !ok {
+		return Ball{}, ErrUnknownBall
+	}
+	if ball.Status != "in_hand" {
+		return Ball{}, ErrNotInHand
+	}
+	min, max := ball.flightRange(j.options.MinFlightTime, j.options.MaxFlightTime)
+	if height != 0 && (height < min || height > max) {
+		return Ball{}, &i18n.Error{Key: "juggler.height", Args: []any{min, max}, Err: ErrHeight}
+	}
+
+	if height == 0 {
+		height = j.randomFlightTime(ballID)
+	}
+	thrownFrom, thrower := ball.Hand, ball.Performer
+	j.throwBall(ballID, height)
+
+	if _, ok := j.balls[ballID]; ok {
+		session := j.session
+		j.flights.Go(func() error {
+			return j.flyBall(context.Background(), session, ballID)
+		})
+	}
+
+	thrown := *ball
+	thrown.Hand, thrown.Performer = thrownFrom, thrower
+	return thrown, nil
+}
+
+// handLoad returns how many balls the given hand of a performer holds.
+// The caller must hold the lock.
+func (j *Juggler) handLoad(performer int, hand string) int {
+	n := 0
+	for _, id := range j.ballsInHand {
+		if ball := j.balls[id]; ball.Performer == performer && ball.Hand == hand {
+			n++
+		}
+	}
+	return n
+}
+
+// dropHeldBalls drops balls held longer than the hold time, which in
+// manual mode means the player missed the beat. The caller must hold the lock.
+func (j *Juggler) dropHeldBalls() {
+	now := j.clock.Now()
+	held := append([]int(nil), j.ballsInHand...)
+	for _, id := range held {
+		if now.Sub(j.balls[id].heldSince) < j.options.holdTime() {
+			continue
+		}
+		for i, handID := range j.ballsInHand {
+			if handID == id {
+				j.ballsInHand = append(j.ballsInHand[:i], j.ballsInHand[i+1:]...)
+				break
+			}
+		}
+		j.dropBall(id)
+	}
+}
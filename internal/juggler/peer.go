@@ -0,0 +1,159 @@
+package juggler
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Pass is a ball thrown to a juggler in another process
+type Pass struct {
+	BallID     int       `json:"ball_id"` // ID of the ball at the thrower
+	Session    int       `json:"session"` // the thrower's session; ball IDs restart in every session
+	FlightTime int       `json:"flight_time"`
+	ThrownAt   time.Time `json:"thrown_at"` // on the clock of whoever holds the Pass
+	BallDef    BallDef   `json:"ball_def,omitzero"`
+}
+
+// PeerLink carries balls to a juggler in another process. Its methods are
+// called with the juggler's lock held, so they must not block or call
+// back into the juggler.
+type PeerLink interface {
+	// Pass sends a ball thrown to the peer
+	Pass(p Pass)
+	// Landed reports that a ball received from the peer has been caught or
+	// dropped; session and remoteID are the Pass's
+	Landed(session, remoteID int, caught bool)
+}
+
+// SetPeer connects the juggler to a peer; every Options.PassEvery-th
+// throw then goes to the peer. nil disconnects it. Balls still flying
+// from the previous peer leave the session: that peer counts them as
+// dropped.
+func (j *Juggler) SetPeer(link PeerLink) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.peer != nil && link != j.peer {
+		j.abandonPasses()
+	}
+	j.peer = link
+}
+
+// abandonPasses removes the balls passed by the peer that have not landed.
+// The caller must hold the lock.
+func (j *Juggler) abandonPasses() {
+	for id, ball := range j.balls {
+		if ball.remoteID == 0 {
+			continue
+		}
+		j.removeFromAir(id)
+		delete(j.balls, id)
+		j.totalBalls--
+	}
+}
+
+// passesToPeer reports whether the next throw goes to the peer and counts
+// it. The caller must hold the lock.
+func (j *Juggler) passesToPeer() bool {
+	if j.peer == nil || j.options.PassEvery == 0 {
+		return false
+	}
+	j.peerThrows++
+	return j.peerThrows%j.options.PassEvery == 0
+}
+
+// passToPeer throws a ball to the peer; it leaves this juggler at once.
+// The caller must hold the lock and have taken the ball out of hand.
+func (j *Juggler) passToPeer(ballID, flightTime int) {
+	ball := j.balls[ballID]
+	now := j.clock.Now()
+
+	thrower, _ := j.route(ball)
+	j.events = append(j.events, Event{
+		Time:       now,
+		BallID:     ballID,
+		Type:       "throw",
+		Hand:       ball.Hand,
+		FlightTime: flightTime,
+		Performer:  thrower,
+		Remote:     true,
+	})
+	delete(j.balls, ballID)
+	j.totalBalls--
+
+	j.peer.Pass(Pass{BallID: ballID, Session: j.session, FlightTime: flightTime, ThrownAt: now, BallDef: ball.BallDef})
+}
+
+// ReceivePass takes a ball thrown by the peer, with ThrownAt already on
+// this juggler's clock. The ball gets a new ID, which is returned, and
+// lands in flight time like any other; Landed reports the outcome.
+func (j *Juggler) ReceivePass(p Pass) (int, error) {
+	if err := p.BallDef.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid ball: %w", err)
+	}
+	if p.FlightTime < 1 {
+		return 0, fmt.Errorf("invalid flight time %d", p.FlightTime)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.flights == nil || j.finished || j.elapsed() >= j.jugglingTime || j.peer == nil {
+		return 0, ErrNotRunning
+	}
+
+	// Whole seconds already flown, as the flight advances once per second
+	elapsed := int(j.clock.Now().Sub(p.ThrownAt) / time.Second)
+	elapsed = clampInt(elapsed, 0, p.FlightTime-1)
+
+	id := j.nextBallID
+	j.nextBallID++
+	j.totalBalls++
+	j.balls[id] = &Ball{
+		ID:            id,
+		Status:        "in_flight",
+		FlightTime:    p.FlightTime,
+		Elapsed:       elapsed,
+		StartTime:     j.clock.Now().Add(-time.Duration(elapsed) * time.Second),
+		Hand:          startingHand(id),
+		BallDef:       p.BallDef,
+		remoteID:      p.BallID,
+		remoteSession: p.Session,
+	}
+	j.ballsInAir = append(j.ballsInAir, id)
+
+	session := j.session
+	j.flights.Go(func() error {
+		return j.flyBall(context.Background(), session, id)
+	})
+	return id, nil
+}
+
+// LosePass records that a ball passed to the peer in the given session
+// never arrived, for example because the connection broke while it was in
+// transit. It counts as a drop; passes of earlier sessions are ignored.
+func (j *Juggler) LosePass(session, ballID int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if session != j.session {
+		return
+	}
+
+	j.events = append(j.events, Event{
+		Time:   j.clock.Now(),
+		BallID: ballID,
+		Type:   "drop",
+		Remote: true,
+	})
+}
+
+// landedFromPeer tells the peer how a ball it passed has landed.
+// The caller must hold the lock.
+func (j *Juggler) landedFromPeer(ball *Ball) {
+	if ball.remoteID == 0 || j.peer == nil {
+		return
+	}
+	j.peer.Landed(ball.remoteSession, ball.remoteID, ball.Status == "in_hand")
+	ball.remoteID = 0
+}
@@ -0,0 +1,164 @@
+package juggler
+
+import (
+	"math"
+	"time"
+
+	"juggler/internal/i18n"
+)
+
+// Performer model defaults
+const (
+	DefaultFatigue  = 0.005 // stamina lost per throw
+	DefaultRecovery = 0.001 // stamina regained per second
+	MaxMissChance   = 0.05  // chance of missing a catch with no form at all
+)
+
+// PerformerOptions tunes the performer model. With a skill of 0, the
+// default, the model is off: throws are uniformly random within the
+// flight time range and only DropChance makes balls fall.
+type PerformerOptions struct {
+	Skill    float64 `json:"skill"`    // 0 to 1, where 1 is a master juggler
+	Fatigue  float64 `json:"fatigue"`  // stamina lost per throw, 0 means DefaultFatigue
+	Recovery float64 `json:"recovery"` // stamina regained per second, 0 means DefaultRecovery
+}
+
+// PerformerState is a live reading of the performer model
+type PerformerState struct {
+	Skill      float64 `json:"skill"`
+	Stamina    float64 `json:"stamina"`     // 1 when fresh, 0 when exhausted
+	Form       float64 `json:"form"`        // skill reduced by fatigue; drives accuracy and catches
+	MissChance float64 `json:"miss_chance"` // chance of missing the next catch, on top of DropChance
+}
+
+// Validate validates the performer options
+func (o PerformerOptions) Validate() error {
+	if o.Skill < 0 || o.Skill > 1 {
+		return i18n.NewError("juggler.skill")
+	}
+	if o.Fatigue < 0 || o.Fatigue > 1 || o.Recovery < 0 || o.Recovery > 1 {
+		return i18n.NewError("juggler.fatigue")
+	}
+	return nil
+}
+
+// enabled reports whether the performer model is on
+func (o PerformerOptions) enabled() bool {
+	return o.Skill > 0
+}
+
+// fatigue returns the stamina lost per throw
+func (o PerformerOptions) fatigue() float64 {
+	if o.Fatigue == 0 {
+		return DefaultFatigue
+	}
+	return o.Fatigue
+}
+
+// recovery returns the stamina regained per second
+func (o PerformerOptions) recovery() float64 {
+	if o.Recovery == 0 {
+		return DefaultRecovery
+	}
+	return o.Recovery
+}
+
+// stamina is a performer's stamina at a moment, see PerformerOptions
+type stamina struct {
+	level float64
+	at    time.Time
+}
+
+// GetPerformer returns the state of the performer model, or false if it is
+// off. A troupe reports its most tired performer, the first to drop;
+// GetTroupe has the state of each.
+func (j *Juggler) GetPerformer() (PerformerState, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	if !j.options.Performer.enabled() {
+		return PerformerState{}, false
+	}
+	if j.pattern == nil {
+		return j.performerState(0), true
+	}
+	tired := 1
+	for p := 2; p <= j.pattern.Performers(); p++ {
+		if j.currentStamina(p) < j.currentStamina(tired) {
+			tired = p
+		}
+	}
+	return j.performerState(tired), true
+}
+
+// performerState returns the live reading of a performer. The caller must
+// hold the lock.
+func (j *Juggler) performerState(performer int) PerformerState {
+	return PerformerState{
+		Skill:      j.options.Performer.Skill,
+		Stamina:    j.currentStamina(performer),
+		Form:       j.form(performer),
+		MissChance: j.missChance(performer),
+	}
+}
+
+// currentStamina returns a performer's stamina including what they have
+// regained since their last throw. Performers are keyed as Ball.Performer,
+// 0 for a solo juggler. The caller must hold the lock.
+func (j *Juggler) currentStamina(performer int) float64 {
+	s, ok := j.stamina[performer]
+	if !ok {
+		return 1
+	}
+	rested := j.clock.Now().Sub(s.at).Seconds()
+	return math.Min(1, s.level+rested*j.options.Performer.recovery())
+}
+
+// tire spends a performer's stamina on a throw. The caller must hold the lock.
+func (j *Juggler) tire(performer int) {
+	j.stamina[performer] = stamina{
+		level: math.Max(0, j.currentStamina(performer)-j.options.Performer.fatigue()),
+		at:    j.clock.Now(),
+	}
+}
+
+// form returns the skill reduced by fatigue: an exhausted performer
+// juggles at half their skill. The caller must hold the lock.
+func (j *Juggler) form(performer int) float64 {
+	return j.options.Performer.Skill * (0.5 + 0.5*j.currentStamina(performer))
+}
+
+// missChance returns the chance that a performer misses a catch.
+// The caller must hold the lock.
+func (j *Juggler) missChance(performer int) float64 {
+	if !j.options.Performer.enabled() {
+		return 0
+	}
+	return MaxMissChance * (1 - j.form(performer))
+}
+
+// aimedFlightTime picks the flight time of a performer's throw aimed at
+// the middle of the range; the worse their form, the further it strays.
+// The caller must hold the lock.
+func (j *Juggler) aimedFlightTime(performer, min, max int) int {
+	mid := float64(min+max) / 2
+	spread := float64(max-min)/2*(1-j.form(performer)) + 0.5
+	t := int(math.Round(mid + j.rng.NormFloat64()*spread))
+	return clampInt(t, min, max)
+}
+
+// resetStamina gives every performer full stamina. The caller must hold the lock.
+func (j *Juggler) resetStamina() {
+	j.stamina = make(map[int]stamina)
+}
+
+// clampInt limits v to the range from min to max
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,155 @@
+package juggler
+
+import (
+	"fmt"
+	"regexp"
+
+	"juggler/internal/i18n"
+)
+
+// Prop types
+const (
+	PropBall = "ball"
+	PropClub = "club"
+	PropRing = "ring"
+)
+
+// Defaults for ball definitions that leave size or weight unset
+const (
+	DefaultSize   = 7   // centimetres
+	DefaultWeight = 120 // grams
+)
+
+// Limits of ball definitions
+const (
+	maxPropName   = 32
+	maxPropSize   = 50
+	maxPropWeight = 1000
+)
+
+// colorPattern matches the #rrggbb colors the web UI can render
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// typeDropFactor scales the drop chance by prop type: clubs spin and
+// rings are caught by the edge, so both are harder to catch than balls
+var typeDropFactor = map[string]float64{
+	PropBall: 1,
+	PropClub: 1.5,
+	PropRing: 1.25,
+}
+
+// BallDef describes what a ball looks like and how it flies. The zero
+// value is a standard ball.
+type BallDef struct {
+	Name   string `json:"name,omitempty"`
+	Color  string `json:"color,omitempty"`  // "#rrggbb"
+	Size   int    `json:"size,omitempty"`   // centimetres, 0 means DefaultSize
+	Weight int    `json:"weight,omitempty"` // grams, 0 means DefaultWeight
+	Type   string `json:"type,omitempty"`   // "ball" (default), "club", "ring"
+}
+
+// Validate validates the ball definition
+func (d BallDef) Validate() error {
+	if len([]rune(d.Name)) > maxPropName {
+		return i18n.NewError("juggler.prop_name", maxPropName)
+	}
+	if d.Color != "" && !colorPattern.MatchString(d.Color) {
+		return i18n.NewError("juggler.prop_color")
+	}
+	if d.Size < 0 || d.Size > maxPropSize {
+		return i18n.NewError("juggler.prop_size", maxPropSize)
+	}
+	if d.Weight < 0 || d.Weight > maxPropWeight {
+		return i18n.NewError("juggler.prop_weight", maxPropWeight)
+	}
+	if _, ok := typeDropFactor[d.propType()]; !ok {
+		return i18n.NewError("juggler.prop_type")
+	}
+	return nil
+}
+
+// propType returns the prop type, defaulting to a ball
+func (d BallDef) propType() string {
+	if d.Type == "" {
+		return PropBall
+	}
+	return d.Type
+}
+
+// size returns the diameter in centimetres
+func (d BallDef) size() int {
+	if d.Size == 0 {
+		return DefaultSize
+	}
+	return d.Size
+}
+
+// weight returns the weight in grams
+func (d BallDef) weight() int {
+	if d.Weight == 0 {
+		return DefaultWeight
+	}
+	return d.Weight
+}
+
+// flightRange adjusts the flight time range for the prop. Clubs need a
+// second more to turn over, and every 100 grams above the default weight
+// shortens the highest throw by a second.
+func (d BallDef) flightRange(min, max int) (int, int) {
+	if d.propType() == PropClub {
+		min++
+		max++
+	}
+	if w := d.weight(); w > DefaultWeight {
+		max -= (w - DefaultWeight) / 100
+	}
+	if max < min {
+		max = min
+	}
+	return min, max
+}
+
+// dropChance adjusts the drop chance for the prop: heavier and smaller
+// props are harder to catch
+func (d BallDef) dropChance(base float64) float64 {
+	chance := base * typeDropFactor[d.propType()]
+	chance *= 0.5 + 0.5*float64(d.weight())/DefaultWeight
+	chance *= float64(DefaultSize) / float64(d.size())
+	if chance > 1 {
+		return 1
+	}
+	return chance
+}
+
+// SetBallDefs sets the definitions of the balls of subsequent sessions.
+// Ball N gets definition N, cycling through defs when there are more balls,
+// so a single definition applies to every ball. No definitions means
+// standard balls.
+func (j *Juggler) SetBallDefs(defs []BallDef) error {
+	for i, d := range defs {
+		if err := d.Validate(); err != nil {
+			return fmt.Errorf("ball %d: %w", i+1, err)
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.ballDefs = append([]BallDef(nil), defs...)
+	return nil
+}
+
+// GetBallDefs returns the ball definitions set with SetBallDefs
+func (j *Juggler) GetBallDefs() []BallDef {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return append([]BallDef(nil), j.ballDefs...)
+}
+
+// ballDef returns the definition of the ball with the given ID.
+// The caller must hold the lock.
+func (j *Juggler) ballDef(ballID int) BallDef {
+	if len(j.ballDefs) == 0 {
+		return BallDef{}
+	}
+	return j.ballDefs[(ballID-1)%len(j.ballDefs)]
+}
@@ -0,0 +1,73 @@
+package juggler
+
+import "time"
+
+// Simulate plays the current session to the end on a virtual clock,
+// without goroutines or sleeping, so a session of any length completes
+// in a fraction of a millisecond. The juggler must have been given clock
+// with SetClock before Reset. Throws happen every throw interval and
+// balls advance once per second of flight, exactly as with Start. In
+// manual mode nothing is thrown and held balls are dropped in time. Like
+// Start, it does nothing for a session that has already been started.
+func (j *Juggler) Simulate(clock *VirtualClock) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.started {
+		return
+	}
+	j.started = true
+
+	interval := j.options.ThrowInterval
+	nextThrow := j.startTime.Add(interval)
+
+	for !j.finished || len(j.ballsInAir) > 0 {
+		next, ok := j.nextBallTick()
+		if !j.finished && (!ok || nextThrow.Before(next)) {
+			next = nextThrow
+		}
+		clock.Set(next)
+
+		inAir := append([]int(nil), j.ballsInAir...)
+		for _, id := range inAir {
+			if !j.ballTickTime(id).After(next) {
+				j.advanceBall(id)
+			}
+		}
+
+		if !j.finished && !nextThrow.After(next) {
+			if j.elapsed() >= j.jugglingTime {
+				j.finished = true
+			} else if j.options.Manual {
+				j.dropHeldBalls()
+			} else {
+				for j.throw() {
+				}
+			}
+			nextThrow = nextThrow.Add(interval)
+		}
+	}
+
+	close(j.done)
+}
+
+// nextBallTick returns the earliest time a ball in the air advances.
+// The caller must hold the lock.
+func (j *Juggler) nextBallTick() (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, id := range j.ballsInAir {
+		t := j.ballTickTime(id)
+		if !found || t.Before(next) {
+			next, found = t, true
+		}
+	}
+	return next, found
+}
+
+// ballTickTime returns when the given ball in the air next advances.
+// The caller must hold the lock.
+func (j *Juggler) ballTickTime(ballID int) time.Time {
+	ball := j.balls[ballID]
+	return ball.StartTime.Add(time.Duration(ball.Elapsed+1) * time.Second)
+}
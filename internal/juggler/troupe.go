@@ -0,0 +1,101 @@
+package juggler
+
+// TroupeMember holds the figures of one performer of a troupe
+type TroupeMember struct {
+	Performer      int `json:"performer"`
+	InHand         int `json:"in_hand"`
+	Incoming       int `json:"incoming"` // balls in the air on their way to the performer
+	Throws         int `json:"throws"`   // self-throws and passes
+	PassesMade     int `json:"passes_made"`
+	PassesReceived int `json:"passes_received"` // passes caught
+	Catches        int `json:"catches"`
+	Drops          int `json:"drops"`
+
+	State *PerformerState `json:"state,omitempty"` // gauges of the performer model, if it is on
+}
+
+// route picks who catches a ball being thrown by following the passing
+// pattern, and returns the thrower and, for a pass, the receiver. A solo
+// juggler returns zeros. The caller must hold the lock.
+func (j *Juggler) route(ball *Ball) (thrower, to int) {
+	if j.pattern == nil {
+		return 0, 0
+	}
+
+	n := j.pattern.Performers()
+	thrower = ball.Performer
+	if thrower < 1 || thrower > n {
+		// The pattern changed during the session
+		thrower = (ball.ID-1)%n + 1
+	}
+
+	ball.Performer = j.pattern.Target(thrower, j.throwCounts[thrower])
+	j.throwCounts[thrower]++
+	if ball.Performer == thrower {
+		return thrower, 0
+	}
+	ball.PassFrom = thrower
+	return thrower, ball.Performer
+}
+
+// GetTroupe returns the figures of every performer, or nil for a solo juggler
+func (j *Juggler) GetTroupe() []TroupeMember {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	if j.pattern == nil {
+		return nil
+	}
+
+	members := make([]TroupeMember, j.pattern.Performers())
+	member := func(performer int) *TroupeMember {
+		if performer < 1 || performer > len(members) {
+			return nil
+		}
+		return &members[performer-1]
+	}
+	for i := range members {
+		members[i].Performer = i + 1
+		if j.options.Performer.enabled() {
+			state := j.performerState(i + 1)
+			members[i].State = &state
+		}
+	}
+
+	for _, ball := range j.balls {
+		m := member(ball.Performer)
+		if m == nil {
+			continue
+		}
+		switch ball.Status {
+		case "in_hand":
+			m.InHand++
+		case "in_flight":
+			m.Incoming++
+		}
+	}
+
+	passed := make(map[int]bool) // whether each ball's last throw was a pass
+	for _, e := range j.events {
+		m := member(e.Performer)
+		if m == nil {
+			continue
+		}
+		switch e.Type {
+		case "throw":
+			m.Throws++
+			passed[e.BallID] = e.To != 0
+			if e.To != 0 {
+				m.PassesMade++
+			}
+		case "catch":
+			m.Catches++
+			if passed[e.BallID] {
+				m.PassesReceived++
+			}
+		case "drop":
+			m.Drops++
+		}
+	}
+	return members
+}
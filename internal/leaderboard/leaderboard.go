@@ -0,0 +1,213 @@
+// Package leaderboard stores player profiles and their finished runs in a
+// local JSON file and ranks the runs
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"juggler/internal/i18n"
+)
+
+// maxNameLength bounds player names in characters
+const maxNameLength = 32
+
+// Errors returned by the store
+var (
+	ErrPlayerExists  = errors.New("player already exists")
+	ErrUnknownPlayer = errors.New("unknown player")
+	ErrInvalidName   = i18n.NewError("leaderboard.invalid_name", maxNameLength)
+	ErrUnknownMetric = errors.New("unknown metric")
+)
+
+// Player is a named profile that sessions can be attributed to
+type Player struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Run is the result of one finished session of a player
+type Run struct {
+	Player           string    `json:"player"`
+	Session          int       `json:"session"`
+	Balls            int       `json:"balls"`
+	Manual           bool      `json:"manual"`
+	StartTime        time.Time `json:"start_time"`
+	Seconds          int       `json:"seconds"`
+	Points           int       `json:"points"`
+	LongestStreak    int       `json:"longest_streak"`
+	Catches          int       `json:"catches"`
+	Drops            int       `json:"drops"`
+	CatchesPerMinute float64   `json:"catches_per_minute"`
+}
+
+// Metrics maps the names runs can be ranked by to their values; higher is better
+var Metrics = map[string]func(Run) float64{
+	"points":             func(r Run) float64 { return float64(r.Points) },
+	"longest_streak":     func(r Run) float64 { return float64(r.LongestStreak) },
+	"catches":            func(r Run) float64 { return float64(r.Catches) },
+	"catches_per_minute": func(r Run) float64 { return r.CatchesPerMinute },
+}
+
+// Store holds players and runs, saving them to a file after every change
+// unless it was created with NewStore
+type Store struct {
+	mu      sync.RWMutex
+	path    string
+	players []Player
+	runs    []Run
+}
+
+// storeFile is the on-disk format of a store
+type storeFile struct {
+	Players []Player `json:"players"`
+	Runs    []Run    `json:"runs"`
+}
+
+// NewStore creates an in-memory store
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Open loads the store saved at path, or starts an empty one if the file does not exist
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f storeFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("reading leaderboard %s: %v", path, err)
+	}
+	s.players = f.Players
+	s.runs = f.Runs
+	return s, nil
+}
+
+// AddPlayer creates a profile; names are unique regardless of case
+func (s *Store) AddPlayer(name string) (Player, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxNameLength {
+		return Player{}, ErrInvalidName
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.find(name) >= 0 {
+		return Player{}, ErrPlayerExists
+	}
+
+	p := Player{Name: name, CreatedAt: time.Now()}
+	s.players = append(s.players, p)
+	return p, s.save()
+}
+
+// Players returns the profiles in the order they were created
+func (s *Store) Players() []Player {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Player(nil), s.players...)
+}
+
+// Player returns the profile with the given name, ignoring case
+func (s *Store) Player(name string) (Player, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.find(strings.TrimSpace(name))
+	if i < 0 {
+		return Player{}, false
+	}
+	return s.players[i], true
+}
+
+// Record adds a run of an existing player
+func (s *Store) Record(run Run) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(run.Player)
+	if i < 0 {
+		return ErrUnknownPlayer
+	}
+	run.Player = s.players[i].Name
+
+	s.runs = append(s.runs, run)
+	return s.save()
+}
+
+// Top returns up to limit runs ranked by metric, best first, optionally
+// only those with the given number of balls (0 for any). Ties go to the
+// earlier run.
+func (s *Store) Top(metric string, balls, limit int) ([]Run, error) {
+	value, ok := Metrics[metric]
+	if !ok {
+		return nil, ErrUnknownMetric
+	}
+
+	s.mu.RLock()
+	runs := make([]Run, 0, len(s.runs))
+	for _, r := range s.runs {
+		if balls == 0 || r.Balls == balls {
+			runs = append(runs, r)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(runs, func(a, b int) bool { return value(runs[a]) > value(runs[b]) })
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+// find returns the index of the named player or -1. The caller must hold the lock.
+func (s *Store) find(name string) int {
+	for i, p := range s.players {
+		if strings.EqualFold(p.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes the store to its file, replacing it atomically. The caller must hold the lock.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(storeFile{Players: s.players, Runs: s.runs}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".leaderboard-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
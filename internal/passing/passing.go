@@ -0,0 +1,123 @@
+// Package passing parses passing patterns, which say for every throw of
+// every performer in a troupe whether it is a self-throw or a pass
+package passing
+
+import (
+	"strconv"
+	"strings"
+
+	"juggler/internal/i18n"
+)
+
+// MaxPerformers bounds the size of a troupe
+const MaxPerformers = 8
+
+// Pattern is a parsed passing pattern. Performers are numbered from 1.
+type Pattern struct {
+	Notation string
+	Throws   [][]int // per performer, the performer each throw goes to
+}
+
+// Parse parses a pattern such as "p s | p s". Performers are separated by
+// "|" and each lists their throws in order, repeating: "s" is a self-throw,
+// "p" a pass to the next performer and "pN" a pass to performer N. Every
+// performer must receive as many passes as they make per cycle, or balls
+// would pile up on one of them.
+func Parse(notation string) (*Pattern, error) {
+	notation = strings.TrimSpace(notation)
+	parts := strings.Split(notation, "|")
+	if len(parts) < 2 || len(parts) > MaxPerformers {
+		return nil, i18n.NewError("passing.performers", MaxPerformers)
+	}
+
+	p := &Pattern{Notation: notation, Throws: make([][]int, len(parts))}
+	for i, part := range parts {
+		performer := i + 1
+		tokens := strings.Fields(strings.ToLower(part))
+		if len(tokens) == 0 {
+			return nil, i18n.NewError("passing.no_throws", performer)
+		}
+
+		for _, tok := range tokens {
+			to, err := parseThrow(tok, performer, len(parts))
+			if err != nil {
+				return nil, i18n.NewError("passing.performer", performer, err)
+			}
+			p.Throws[i] = append(p.Throws[i], to)
+		}
+	}
+
+	if err := p.checkBalance(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parseThrow returns the performer a throw token goes to
+func parseThrow(tok string, performer, performers int) (int, error) {
+	switch {
+	case tok == "s":
+		return performer, nil
+	case tok == "p":
+		return performer%performers + 1, nil
+	case strings.HasPrefix(tok, "p"):
+		to, err := strconv.Atoi(tok[1:])
+		if err != nil || to < 1 || to > performers {
+			return 0, i18n.NewError("passing.invalid_pass", tok)
+		}
+		if to == performer {
+			return 0, i18n.NewError("passing.self_pass", tok)
+		}
+		return to, nil
+	default:
+		return 0, i18n.NewError("passing.invalid_throw", tok)
+	}
+}
+
+// checkBalance checks that over a full cycle of the pattern every
+// performer receives as many passes as they make
+func (p *Pattern) checkBalance() error {
+	cycle := 1
+	for _, throws := range p.Throws {
+		cycle = lcm(cycle, len(throws))
+	}
+
+	balance := make([]int, len(p.Throws))
+	for i, throws := range p.Throws {
+		repeats := cycle / len(throws)
+		for _, to := range throws {
+			if to != i+1 {
+				balance[i] -= repeats
+				balance[to-1] += repeats
+			}
+		}
+	}
+
+	for i, b := range balance {
+		if b != 0 {
+			return i18n.NewError("passing.unbalanced", i+1, b)
+		}
+	}
+	return nil
+}
+
+// Performers returns the number of performers in the troupe
+func (p *Pattern) Performers() int {
+	return len(p.Throws)
+}
+
+// Target returns the performer that the given throw of a performer goes
+// to; throws are counted from 0 and the pattern repeats
+func (p *Pattern) Target(performer, throw int) int {
+	throws := p.Throws[performer-1]
+	return throws[throw%len(throws)]
+}
+
+// lcm returns the least common multiple of a and b
+func lcm(a, b int) int {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
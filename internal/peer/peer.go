@@ -0,0 +1,493 @@
+// Package peer links the jugglers of two processes over TCP so that they
+// can pass balls to each other.
+//
+// The protocol is one JSON Message per line. The dialing side sends
+// "hello" and the listening side answers "welcome", or "error" if it
+// already has a peer or speaks another version. Both sides then ping each
+// other to estimate the offset between their clocks, send thrown balls as
+// "pass" and answer every pass with "landed" once the ball is caught or
+// dropped, or with "rejected" if it could not be taken. A ball is named by
+// the thrower's session and ball ID, as IDs restart in every session.
+// Balls in transit when the connection breaks count as dropped by the
+// thrower and leave the receiver.
+package peer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"juggler/internal/i18n"
+	"juggler/internal/juggler"
+)
+
+// ProtocolVersion is the version both sides must agree on in the handshake
+const ProtocolVersion = 2
+
+// Message types
+const (
+	MsgHello    = "hello"
+	MsgWelcome  = "welcome"
+	MsgError    = "error"
+	MsgPing     = "ping"
+	MsgPong     = "pong"
+	MsgPass     = "pass"
+	MsgLanded   = "landed"
+	MsgRejected = "rejected"
+)
+
+// Protocol timings
+const (
+	HandshakeTimeout = 5 * time.Second
+	PingInterval     = 2 * time.Second
+	writeTimeout     = 5 * time.Second
+	sendQueue        = 256
+)
+
+// Message is one line of the protocol
+type Message struct {
+	Type    string        `json:"type"`
+	Version int           `json:"version,omitempty"` // hello, welcome
+	Name    string        `json:"name,omitempty"`    // hello, welcome
+	Reason  string        `json:"reason,omitempty"`  // error, rejected
+	Seq     int           `json:"seq,omitempty"`     // ping, pong
+	Sent    time.Time     `json:"sent,omitzero"`     // ping, pong: pinger's clock when the ping left
+	Time    time.Time     `json:"time,omitzero"`     // pong: the answering side's clock
+	Pass    *juggler.Pass `json:"pass,omitempty"`    // pass, with ThrownAt on the thrower's clock
+	BallID  int           `json:"ball_id,omitempty"` // landed, rejected: the ball's ID at the thrower
+	Session int           `json:"session,omitempty"` // landed, rejected: the thrower's session of the ball
+	Caught  bool          `json:"caught,omitempty"`  // landed
+}
+
+// Status describes the link to the peer
+type Status struct {
+	Name        string  `json:"name"` // this side's name
+	Connected   bool    `json:"connected"`
+	Peer        string  `json:"peer,omitempty"`    // name the peer gave in the handshake
+	Address     string  `json:"address,omitempty"` // the peer's address
+	ClockOffset float64 `json:"clock_offset_ms"`   // the peer's clock minus ours
+	RTT         float64 `json:"rtt_ms"`            // round trip of the ping the offset was taken from
+	InTransit   int     `json:"in_transit"`        // balls passed to the peer that have not landed
+	Passed      int     `json:"passed"`            // balls passed to the peer
+	Received    int     `json:"received"`          // balls taken from the peer
+	Lost        int     `json:"lost"`              // passes that never arrived
+}
+
+// Node connects a juggler to at most one peer at a time
+type Node struct {
+	juggler *juggler.Juggler
+	name    string
+
+	// attachMu serializes connecting and disconnecting, which set the
+	// juggler's peer. It is never taken while the juggler's lock is held.
+	attachMu sync.Mutex
+
+	mu       sync.Mutex
+	link     *link
+	passed   int
+	received int
+	lost     int
+}
+
+// NewNode creates a node for the given juggler; name identifies it to peers
+func NewNode(j *juggler.Juggler, name string) *Node {
+	return &Node{juggler: j, name: name}
+}
+
+// Listen accepts peers on addr in the background, one at a time
+func (n *Node) Listen(addr string) (net.Listener, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				if err := n.accept(conn); err != nil {
+					log.Print(i18n.L("log.peer_rejected", conn.RemoteAddr(), err))
+				}
+			}()
+		}
+	}()
+	return l, nil
+}
+
+// Dial connects to the peer listening on addr
+func (n *Node) Dial(addr string) error {
+	conn, err := net.DialTimeout("tcp", addr, HandshakeTimeout)
+	if err != nil {
+		return err
+	}
+
+	conn.SetDeadline(time.Now().Add(HandshakeTimeout))
+	enc, dec := json.NewEncoder(conn), json.NewDecoder(conn)
+	if err := enc.Encode(Message{Type: MsgHello, Version: ProtocolVersion, Name: n.name}); err != nil {
+		conn.Close()
+		return err
+	}
+
+	var reply Message
+	if err := dec.Decode(&reply); err != nil {
+		conn.Close()
+		return fmt.Errorf("handshake: %v", err)
+	}
+	switch {
+	case reply.Type == MsgError:
+		conn.Close()
+		return fmt.Errorf("peer refused: %s", reply.Reason)
+	case reply.Type != MsgWelcome || reply.Version != ProtocolVersion:
+		conn.Close()
+		return fmt.Errorf("handshake: unexpected %s version %d", reply.Type, reply.Version)
+	}
+	conn.SetDeadline(time.Time{})
+
+	return n.attach(conn, dec, reply.Name)
+}
+
+// KeepDialing connects to addr and reconnects after every disconnect,
+// waiting retry between attempts, until ctx is done
+func (n *Node) KeepDialing(ctx context.Context, addr string, retry time.Duration) {
+	for {
+		if err := n.Dial(addr); err == nil {
+			log.Print(i18n.L("log.peer_connected", addr))
+			n.waitDisconnect(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+	}
+}
+
+// Status returns the state of the link
+func (n *Node) Status() Status {
+	n.mu.Lock()
+	s := Status{Name: n.name, Passed: n.passed, Received: n.received, Lost: n.lost}
+	l := n.link
+	n.mu.Unlock()
+
+	if l != nil {
+		l.mu.Lock()
+		s.Connected = true
+		s.Peer = l.peer
+		s.Address = l.conn.RemoteAddr().String()
+		s.ClockOffset = float64(l.offset) / float64(time.Millisecond)
+		s.RTT = float64(l.rtt) / float64(time.Millisecond)
+		s.InTransit = len(l.transit)
+		l.mu.Unlock()
+	}
+	return s
+}
+
+// Close disconnects the peer
+func (n *Node) Close() {
+	n.mu.Lock()
+	l := n.link
+	n.mu.Unlock()
+	if l != nil {
+		l.close(errors.New("closed"))
+	}
+}
+
+// accept runs the listening side of the handshake
+func (n *Node) accept(conn net.Conn) error {
+	conn.SetDeadline(time.Now().Add(HandshakeTimeout))
+	enc, dec := json.NewEncoder(conn), json.NewDecoder(conn)
+
+	refuse := func(reason string) error {
+		enc.Encode(Message{Type: MsgError, Reason: reason})
+		conn.Close()
+		return errors.New(reason)
+	}
+
+	var hello Message
+	if err := dec.Decode(&hello); err != nil {
+		conn.Close()
+		return fmt.Errorf("handshake: %v", err)
+	}
+	if hello.Type != MsgHello {
+		return refuse(fmt.Sprintf("expected %s, got %s", MsgHello, hello.Type))
+	}
+	if hello.Version != ProtocolVersion {
+		return refuse(fmt.Sprintf("unsupported protocol version %d", hello.Version))
+	}
+
+	n.mu.Lock()
+	busy := n.link != nil
+	n.mu.Unlock()
+	if busy {
+		return refuse("already connected to a peer")
+	}
+
+	if err := enc.Encode(Message{Type: MsgWelcome, Version: ProtocolVersion, Name: n.name}); err != nil {
+		conn.Close()
+		return err
+	}
+	conn.SetDeadline(time.Time{})
+
+	log.Print(i18n.L("log.peer_accepted", hello.Name, conn.RemoteAddr()))
+	return n.attach(conn, dec, hello.Name)
+}
+
+// attach starts exchanging balls over a connection that completed the handshake
+func (n *Node) attach(conn net.Conn, dec *json.Decoder, peerName string) error {
+	l := &link{
+		node:    n,
+		conn:    conn,
+		dec:     dec,
+		peer:    peerName,
+		out:     make(chan Message, sendQueue),
+		transit: make(map[transitKey]bool),
+		closed:  make(chan struct{}),
+	}
+
+	n.attachMu.Lock()
+	defer n.attachMu.Unlock()
+
+	n.mu.Lock()
+	if n.link != nil {
+		n.mu.Unlock()
+		conn.Close()
+		return errors.New("already connected to a peer")
+	}
+	n.link = l
+	n.mu.Unlock()
+
+	n.juggler.SetPeer(l)
+	go l.write()
+	go l.read()
+	go l.ping()
+	return nil
+}
+
+// waitDisconnect blocks until the current link closes or ctx is done
+func (n *Node) waitDisconnect(ctx context.Context) {
+	n.mu.Lock()
+	l := n.link
+	n.mu.Unlock()
+	if l == nil {
+		return
+	}
+
+	select {
+	case <-l.closed:
+	case <-ctx.Done():
+		l.close(ctx.Err())
+	}
+}
+
+// transitKey names a ball passed to the peer
+type transitKey struct {
+	session, ballID int
+}
+
+// link is one connection to a peer. It implements juggler.PeerLink.
+type link struct {
+	node *Node
+	conn net.Conn
+	dec  *json.Decoder
+	peer string
+	out  chan Message
+
+	mu      sync.Mutex
+	transit map[transitKey]bool // balls passed to the peer that have not landed
+	offset  time.Duration       // the peer's clock minus ours
+	rtt     time.Duration       // 0 until the first pong
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Pass sends a ball thrown to the peer
+func (l *link) Pass(p juggler.Pass) {
+	l.mu.Lock()
+	l.transit[transitKey{p.Session, p.BallID}] = true
+	l.mu.Unlock()
+
+	l.node.mu.Lock()
+	l.node.passed++
+	l.node.mu.Unlock()
+
+	l.send(Message{Type: MsgPass, Pass: &p})
+}
+
+// Landed reports how a ball received from the peer has landed
+func (l *link) Landed(session, remoteID int, caught bool) {
+	l.send(Message{Type: MsgLanded, Session: session, BallID: remoteID, Caught: caught})
+}
+
+// send queues a message without blocking; a link that cannot keep up is closed
+func (l *link) send(m Message) {
+	select {
+	case l.out <- m:
+	case <-l.closed:
+	default:
+		// Called with the juggler's lock held, so close in the background
+		go l.close(errors.New("send queue is full"))
+	}
+}
+
+// write sends queued messages until the link closes
+func (l *link) write() {
+	enc := json.NewEncoder(l.conn)
+	for {
+		select {
+		case m := <-l.out:
+			l.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := enc.Encode(m); err != nil {
+				l.close(err)
+				return
+			}
+		case <-l.closed:
+			return
+		}
+	}
+}
+
+// ping measures the clock offset every PingInterval
+func (l *link) ping() {
+	ticker := time.NewTicker(PingInterval)
+	defer ticker.Stop()
+
+	for seq := 1; ; seq++ {
+		l.send(Message{Type: MsgPing, Seq: seq, Sent: time.Now()})
+		select {
+		case <-ticker.C:
+		case <-l.closed:
+			return
+		}
+	}
+}
+
+// read handles incoming messages until the connection breaks
+func (l *link) read() {
+	for {
+		var m Message
+		if err := l.dec.Decode(&m); err != nil {
+			l.close(err)
+			return
+		}
+
+		switch m.Type {
+		case MsgPing:
+			l.send(Message{Type: MsgPong, Seq: m.Seq, Sent: m.Sent, Time: time.Now()})
+		case MsgPong:
+			l.observeClock(m.Sent, m.Time, time.Now())
+		case MsgPass:
+			l.receive(m)
+		case MsgLanded:
+			l.landed(transitKey{m.Session, m.BallID}, false)
+		case MsgRejected:
+			log.Print(i18n.L("log.peer_refused_ball", l.peer, m.BallID, m.Reason))
+			l.landed(transitKey{m.Session, m.BallID}, true)
+		case MsgError:
+			l.close(fmt.Errorf("peer error: %s", m.Reason))
+			return
+		}
+	}
+}
+
+// observeClock updates the clock offset from a ping that left at sent,
+// reached the peer at peerTime and came back at received. The sample with
+// the shortest round trip is kept, as its timing is the most certain.
+func (l *link) observeClock(sent, peerTime, received time.Time) {
+	rtt := received.Sub(sent)
+	if rtt < 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rtt == 0 || rtt <= l.rtt {
+		l.rtt = max(rtt, time.Nanosecond)
+		l.offset = peerTime.Sub(sent.Add(rtt / 2))
+	}
+}
+
+// receive hands a ball from the peer to the juggler, moving its throw
+// time onto our clock
+func (l *link) receive(m Message) {
+	if m.Pass == nil {
+		return
+	}
+	p := *m.Pass
+
+	l.mu.Lock()
+	p.ThrownAt = p.ThrownAt.Add(-l.offset)
+	l.mu.Unlock()
+
+	if _, err := l.node.juggler.ReceivePass(p); err != nil {
+		l.send(Message{Type: MsgRejected, Session: p.Session, BallID: p.BallID, Reason: err.Error()})
+		return
+	}
+
+	l.node.mu.Lock()
+	l.node.received++
+	l.node.mu.Unlock()
+}
+
+// landed takes a ball off the list of balls in transit; a lost ball
+// counts as dropped
+func (l *link) landed(key transitKey, lost bool) {
+	l.mu.Lock()
+	inTransit := l.transit[key]
+	delete(l.transit, key)
+	l.mu.Unlock()
+
+	if inTransit && lost {
+		l.lose([]transitKey{key})
+	}
+}
+
+// lose records balls that never arrived at the peer
+func (l *link) lose(keys []transitKey) {
+	for _, key := range keys {
+		l.node.juggler.LosePass(key.session, key.ballID)
+	}
+	l.node.mu.Lock()
+	l.node.lost += len(keys)
+	l.node.mu.Unlock()
+}
+
+// close disconnects the peer; balls still in transit count as dropped
+func (l *link) close(reason error) {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		l.conn.Close()
+
+		l.node.attachMu.Lock()
+		l.node.mu.Lock()
+		current := l.node.link == l
+		if current {
+			l.node.link = nil
+		}
+		l.node.mu.Unlock()
+		if current {
+			l.node.juggler.SetPeer(nil)
+		}
+		l.node.attachMu.Unlock()
+
+		l.mu.Lock()
+		keys := make([]transitKey, 0, len(l.transit))
+		for key := range l.transit {
+			keys = append(keys, key)
+		}
+		l.transit = make(map[transitKey]bool)
+		l.mu.Unlock()
+		l.lose(keys)
+
+		log.Print(i18n.L("log.peer_disconnected", l.peer, reason))
+	})
+}
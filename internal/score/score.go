@@ -0,0 +1,134 @@
+// Package score turns the event log of a session into points, streaks
+// and achievements
+package score
+
+import (
+	"time"
+
+	"juggler/internal/juggler"
+)
+
+// Scoring rules
+const (
+	CatchPoints      = 10 // points for a catch with up to BonusFromBalls balls
+	BallBonus        = 5  // extra points per catch for every ball above BonusFromBalls
+	BonusFromBalls   = 3  // ball count from which the ball bonus applies
+	StreakBonus      = 50 // points for every StreakBonusEvery consecutive catches
+	StreakBonusEvery = 10
+	DropPenalty      = 25 // points lost per drop; the score never goes below 0
+)
+
+// Achievement is a milestone that can be unlocked during a session
+type Achievement struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Achievements lists every achievement in the order they are reported
+var Achievements = []Achievement{
+	{"first_catch", "First catch", "Catch a ball"},
+	{"streak_10", "Warming up", "10 catches in a row"},
+	{"streak_50", "In the zone", "50 catches in a row"},
+	{"streak_100", "Unstoppable", "100 catches in a row"},
+	{"catches_100", "Centurion", "100 catches in one session"},
+	{"five_ball_minute", "Five for a minute", "5 balls for 1 minute with no drops"},
+	{"seven_balls", "Seven up", "Catch a ball while juggling 7 balls"},
+	{"clean_ten_minutes", "Marathon", "10 minutes with no drops"},
+}
+
+// Score is the outcome of a session so far
+type Score struct {
+	Points           int           `json:"points"`
+	Streak           int           `json:"streak"` // consecutive catches since the last drop
+	LongestStreak    int           `json:"longest_streak"`
+	Catches          int           `json:"catches"`
+	Drops            int           `json:"drops"`
+	CatchesPerMinute float64       `json:"catches_per_minute"`
+	Achievements     []Achievement `json:"achievements"` // unlocked, in the order of Achievements
+}
+
+// Compute scores a session from its events. A catch is worth more the
+// more balls are being juggled, counted as the balls thrown so far minus
+// those dropped, since a dropped ball stays on the floor.
+func Compute(events []juggler.Event) Score {
+	s := Score{Achievements: make([]Achievement, 0)}
+	unlocked := make(map[string]bool)
+
+	thrown := make(map[int]bool)
+	var start, cleanSince, fiveSince time.Time
+	juggling := func() int { return len(thrown) - s.Drops }
+
+	for i, e := range events {
+		if i == 0 {
+			start, cleanSince = e.Time, e.Time
+		}
+
+		switch e.Type {
+		case "throw":
+			thrown[e.BallID] = true
+			if juggling() >= 5 && fiveSince.IsZero() {
+				fiveSince = e.Time
+			}
+		case "catch":
+			s.Catches++
+			s.Streak++
+			if s.Streak > s.LongestStreak {
+				s.LongestStreak = s.Streak
+			}
+
+			s.Points += catchPoints(juggling())
+			if s.Streak%StreakBonusEvery == 0 {
+				s.Points += StreakBonus
+			}
+
+			unlocked["first_catch"] = true
+			unlocked["seven_balls"] = unlocked["seven_balls"] || juggling() >= 7
+		case "drop":
+			s.Drops++
+			s.Streak = 0
+			s.Points -= DropPenalty
+			if s.Points < 0 {
+				s.Points = 0
+			}
+			cleanSince = e.Time
+			fiveSince = time.Time{}
+			if juggling() >= 5 {
+				fiveSince = e.Time
+			}
+		}
+
+		if !fiveSince.IsZero() && e.Time.Sub(fiveSince) >= time.Minute {
+			unlocked["five_ball_minute"] = true
+		}
+		if e.Time.Sub(cleanSince) >= 10*time.Minute {
+			unlocked["clean_ten_minutes"] = true
+		}
+	}
+
+	unlocked["streak_10"] = s.LongestStreak >= 10
+	unlocked["streak_50"] = s.LongestStreak >= 50
+	unlocked["streak_100"] = s.LongestStreak >= 100
+	unlocked["catches_100"] = s.Catches >= 100
+
+	if len(events) > 0 {
+		if minutes := events[len(events)-1].Time.Sub(start).Minutes(); minutes > 0 {
+			s.CatchesPerMinute = float64(s.Catches) / minutes
+		}
+	}
+
+	for _, a := range Achievements {
+		if unlocked[a.ID] {
+			s.Achievements = append(s.Achievements, a)
+		}
+	}
+	return s
+}
+
+// catchPoints returns the points for a catch while juggling the given number of balls
+func catchPoints(balls int) int {
+	if balls <= BonusFromBalls {
+		return CatchPoints
+	}
+	return CatchPoints + BallBonus*(balls-BonusFromBalls)
+}
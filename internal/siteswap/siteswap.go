@@ -0,0 +1,95 @@
+package siteswap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pattern represents a parsed vanilla siteswap
+type Pattern struct {
+	Notation string
+	Throws   []int
+}
+
+// Parse parses a vanilla siteswap such as "531" or "b97531".
+// Throw heights 0-9 are written as digits and 10-35 as letters a-z.
+func Parse(notation string) (*Pattern, error) {
+	notation = strings.TrimSpace(notation)
+	if notation == "" {
+		return nil, fmt.Errorf("siteswap is empty")
+	}
+
+	throws := make([]int, 0, len(notation))
+	for i, r := range strings.ToLower(notation) {
+		switch {
+		case r >= '0' && r <= '9':
+			throws = append(throws, int(r-'0'))
+		case r >= 'a' && r <= 'z':
+			throws = append(throws, int(r-'a')+10)
+		default:
+			return nil, fmt.Errorf("invalid throw %q at position %d", r, i+1)
+		}
+	}
+
+	return &Pattern{
+		Notation: notation,
+		Throws:   throws,
+	}, nil
+}
+
+// Validate parses the notation and checks that it is jugglable
+func Validate(notation string) (*Pattern, error) {
+	p, err := Parse(notation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Period returns the number of throws in one cycle of the pattern
+func (p *Pattern) Period() int {
+	return len(p.Throws)
+}
+
+// Balls returns the number of balls the pattern needs
+func (p *Pattern) Balls() int {
+	sum := 0
+	for _, t := range p.Throws {
+		sum += t
+	}
+	return sum / len(p.Throws)
+}
+
+// Validate checks the average theorem and that no two throws land on the same beat
+func (p *Pattern) Validate() error {
+	period := len(p.Throws)
+
+	sum := 0
+	for _, t := range p.Throws {
+		sum += t
+	}
+	if sum%period != 0 {
+		return fmt.Errorf("average throw %d/%d is not a whole number of balls", sum, period)
+	}
+
+	landings := make(map[int]int, period)
+	for i, t := range p.Throws {
+		beat := (i + t) % period
+		if prev, ok := landings[beat]; ok {
+			return fmt.Errorf("throws at positions %d and %d land on the same beat", prev+1, i+1)
+		}
+		landings[beat] = i
+	}
+
+	return nil
+}
+
+// String returns the notation of the pattern
+func (p *Pattern) String() string {
+	return p.Notation
+}
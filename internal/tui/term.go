@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// MakeRaw switches the terminal attached to f into raw mode using stty and
+// returns a function restoring the previous settings. It works over SSH and
+// needs no cgo, but requires stty to be available.
+func MakeRaw(f *os.File) (restore func() error, err error) {
+	saved, err := stty(f, "-g")
+	if err != nil {
+		return nil, fmt.Errorf("terminal is not interactive: %v", err)
+	}
+
+	if _, err := stty(f, "raw", "-echo"); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		_, err := stty(f, strings.TrimSpace(saved))
+		return err
+	}, nil
+}
+
+// stty runs stty with f as its standard input and returns its output
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("stty %s: %v", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"juggler/internal/juggler"
+)
+
+// ANSI control sequences used to redraw the dashboard in place
+const (
+	altScreenOn  = "\x1b[?1049h"
+	altScreenOff = "\x1b[?1049l"
+	hideCursor   = "\x1b[?25l"
+	showCursor   = "\x1b[?25h"
+	cursorHome   = "\x1b[H"
+	clearLine    = "\x1b[K"
+	clearBelow   = "\x1b[J"
+)
+
+// barWidth is the width in characters of progress bars
+const barWidth = 30
+
+// Dashboard renders the state of a juggler in a terminal and maps key presses to controls
+type Dashboard struct {
+	juggler *juggler.Juggler
+	balls   int
+	minutes int
+	refresh time.Duration
+	message string
+}
+
+// NewDashboard creates a dashboard that starts sessions with the given number of balls and minutes
+func NewDashboard(j *juggler.Juggler, balls, minutes int) *Dashboard {
+	return &Dashboard{
+		juggler: j,
+		balls:   balls,
+		minutes: minutes,
+		refresh: 200 * time.Millisecond,
+		message: "Press s to start juggling",
+	}
+}
+
+// Run draws the dashboard on out until q or Ctrl+C is read from in.
+// The terminal is expected to be in raw mode so keys arrive unbuffered.
+func (d *Dashboard) Run(in io.Reader, out io.Writer) error {
+	keys := make(chan byte)
+	errs := make(chan error, 1)
+	quit := make(chan struct{})
+	defer close(quit)
+
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			if _, err := in.Read(buf); err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case keys <- buf[0]:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	fmt.Fprint(out, altScreenOn+hideCursor)
+	defer fmt.Fprint(out, showCursor+altScreenOff)
+
+	ticker := time.NewTicker(d.refresh)
+	defer ticker.Stop()
+
+	for {
+		io.WriteString(out, d.Render())
+
+		select {
+		case key := <-keys:
+			if !d.HandleKey(key) {
+				return nil
+			}
+		case err := <-errs:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// HandleKey applies the action bound to key and reports whether the dashboard should keep running
+func (d *Dashboard) HandleKey(key byte) bool {
+	j := d.juggler
+
+	switch key {
+	case 'q', 'Q', 3: // 3 is Ctrl+C in raw mode
+		j.Stop()
+		return false
+	case 's', 'S':
+		if j.IsRunning() {
+			d.message = "Already juggling"
+			break
+		}
+		j.Reset(d.balls, d.minutes)
+		j.Start()
+		d.message = fmt.Sprintf("Started %d balls for %d minutes", d.balls, d.minutes)
+	case 'x', 'X':
+		j.Stop()
+		d.message = "Stopped"
+	case 'p', 'P', ' ':
+		switch {
+		case !j.IsRunning():
+			d.message = "Nothing to pause"
+		case j.IsPaused():
+			j.Resume()
+			d.message = "Resumed"
+		default:
+			j.Pause()
+			d.message = "Paused"
+		}
+	case 'a', 'A', '+':
+		id := j.AddBall()
+		d.balls = j.GetTotalBalls()
+		d.message = fmt.Sprintf("Added ball %d", id)
+	}
+
+	return true
+}
+
+// Render returns one frame of the dashboard, including the control codes that redraw it in place
+func (d *Dashboard) Render() string {
+	j := d.juggler
+	inHand, inAir, balls := j.GetStats()
+	sort.Slice(balls, func(a, b int) bool { return balls[a].ID < balls[b].ID })
+
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(fmt.Sprintf(format, args...))
+		b.WriteString(clearLine + "\r\n")
+	}
+
+	b.WriteString(cursorHome)
+	line("🤹 Juggler dashboard")
+	line("")
+
+	status := "stopped"
+	switch {
+	case j.IsPaused():
+		status = "paused"
+	case j.IsRunning():
+		status = "juggling"
+	case j.GetTotalBalls() > 0 && j.IsFinished():
+		status = "finished"
+	}
+
+	elapsed, total := j.GetElapsed(), j.GetJugglingTime()
+	if !j.IsRunning() && !j.IsPaused() {
+		elapsed = 0
+	}
+	line("Status:   %s", status)
+	line("Session:  %s %3.0f/%.0fs", bar(elapsed.Seconds(), total.Seconds()), elapsed.Seconds(), total.Seconds())
+	line("In hand:  %d", inHand)
+	line("In air:   %d", inAir)
+	line("Total:    %d", j.GetTotalBalls())
+	line("")
+
+	for _, ball := range balls {
+		switch ball.Status {
+		case "in_flight":
+			line("Ball %3d  %s %2d/%2ds in flight", ball.ID, bar(float64(ball.Elapsed), float64(ball.FlightTime)), ball.Elapsed, ball.FlightTime)
+		case "in_hand":
+			line("Ball %3d  %s in hand", ball.ID, bar(0, 0))
+		default:
+			line("Ball %3d  %s %s", ball.ID, bar(0, 0), ball.Status)
+		}
+	}
+
+	line("")
+	line("%s", d.message)
+	line("[s] start  [x] stop  [p] pause/resume  [a] add ball  [q] quit")
+	b.WriteString(clearBelow)
+
+	return b.String()
+}
+
+// bar draws a progress bar filled in proportion to value/max
+func bar(value, max float64) string {
+	filled := 0
+	if max > 0 {
+		filled = int(value / max * barWidth)
+	}
+	if filled > barWidth {
+		filled = barWidth
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled) + "]"
+}
@@ -0,0 +1,278 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"juggler/internal/i18n"
+)
+
+// embeddedAssets holds the page templates and the files under /static
+//
+//go:embed assets
+var embeddedAssets embed.FS
+
+// minGzipSize is the smallest response worth compressing
+const minGzipSize = 1024
+
+// staticFile is a file served under /static
+type staticFile struct {
+	content     []byte
+	gzipped     []byte // nil if compressing does not pay off
+	contentType string
+	version     string // hash of the content, used in ETags and asset URLs
+}
+
+// assetStore loads the templates and static files, from the binary or,
+// in development, from disk on every request so that edits show up on reload
+type assetStore struct {
+	fsys fs.FS // holds templates/ and static/
+	dev  bool
+
+	mu        sync.Mutex
+	templates *template.Template
+	files     map[string]*staticFile
+}
+
+// newAssetStore reads the assets in fsys, failing if the templates do not parse
+func newAssetStore(fsys fs.FS, dev bool) (*assetStore, error) {
+	a := &assetStore{fsys: fsys, dev: dev, files: make(map[string]*staticFile)}
+	t, err := a.parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+	a.templates = t
+	return a, nil
+}
+
+// defaultAssets returns the assets built into the binary
+func defaultAssets() *assetStore {
+	sub, err := fs.Sub(embeddedAssets, "assets")
+	if err != nil {
+		panic(err)
+	}
+	a, err := newAssetStore(sub, false)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// SetDevAssets serves the templates and static files from dir, normally
+// internal/web/assets, rereading them on every request and turning off
+// caching, so that front-end edits show up on reload
+func (s *Server) SetDevAssets(dir string) error {
+	a, err := newAssetStore(os.DirFS(dir), true)
+	if err != nil {
+		return err
+	}
+	s.assets = a
+	return nil
+}
+
+// parseTemplates parses the page templates. The language functions are
+// stand-ins until renderPage binds them to the request's language.
+func (a *assetStore) parseTemplates() (*template.Template, error) {
+	funcs := template.FuncMap{"asset": a.url, "languages": i18n.Languages}
+	for name, fn := range pageLanguageFuncs(i18n.English) {
+		funcs[name] = fn
+	}
+	t, err := template.New("").Funcs(funcs).ParseFS(a.fsys, "templates/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("parsing templates: %v", err)
+	}
+	return t, nil
+}
+
+// template returns the page templates
+func (a *assetStore) template() (*template.Template, error) {
+	if a.dev {
+		return a.parseTemplates()
+	}
+	return a.templates, nil
+}
+
+// url returns the URL of a static file, versioned by its content so that
+// browsers may cache it for good
+func (a *assetStore) url(name string) (string, error) {
+	f, err := a.file(name)
+	if err != nil {
+		return "", err
+	}
+	return "/static/" + name + "?v=" + f.version, nil
+}
+
+// file returns a static file, reading it on first use
+func (a *assetStore) file(name string) (*staticFile, error) {
+	if !fs.ValidPath(name) {
+		return nil, fs.ErrNotExist
+	}
+	if !a.dev {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if f, ok := a.files[name]; ok {
+			return f, nil
+		}
+	}
+
+	content, err := fs.ReadFile(a.fsys, path.Join("static", name))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+	f := &staticFile{
+		content:     content,
+		contentType: mime.TypeByExtension(path.Ext(name)),
+		version:     hex.EncodeToString(sum[:8]),
+	}
+	if f.contentType == "" {
+		f.contentType = http.DetectContentType(content)
+	}
+	if compressible(f.contentType) {
+		f.gzipped = gzipBody(content)
+	}
+
+	if !a.dev {
+		a.files[name] = f
+	}
+	return f, nil
+}
+
+// compressible reports whether content of the type gets smaller with gzip
+func compressible(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.HasPrefix(contentType, "application/javascript") ||
+		strings.HasPrefix(contentType, "application/json") ||
+		strings.HasPrefix(contentType, "image/svg+xml")
+}
+
+// gzipBody compresses b, returning nil if it is too small to bother
+func gzipBody(b []byte) []byte {
+	if len(b) < minGzipSize {
+		return nil
+	}
+	var buf bytes.Buffer
+	zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	zw.Write(b)
+	zw.Close()
+	if buf.Len() >= len(b) {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// acceptsGzip reports whether the client takes gzip-compressed responses
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.TrimSpace(coding) == "gzip" {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// writeBody writes a response body, compressed if the client accepts gzip
+// and gzipped is not nil
+func writeBody(w http.ResponseWriter, r *http.Request, content, gzipped []byte) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if gzipped != nil && acceptsGzip(r) {
+		w.Header().Set("Content-Encoding", "gzip")
+		content = gzipped
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
+
+// pageLanguageFuncs returns the template functions of a page in lang:
+// t translates a key, lang names the language for the language picker and
+// messages holds the messages of the page's script
+func pageLanguageFuncs(lang string) template.FuncMap {
+	return template.FuncMap{
+		"t":        func(key string, args ...any) string { return i18n.T(lang, key, args...) },
+		"lang":     func() string { return lang },
+		"messages": func() map[string]string { return i18n.Catalog(lang, "js.") },
+	}
+}
+
+// renderPage renders a page template in the request's language. Pages
+// are not cached, as they carry the current asset versions.
+func (s *Server) renderPage(w http.ResponseWriter, r *http.Request, name string, data any) {
+	t, err := s.assets.template()
+	if err == nil {
+		t, err = t.Clone()
+	}
+	if err == nil {
+		var buf bytes.Buffer
+		lang := language(r)
+		t.Funcs(pageLanguageFuncs(lang))
+		if err = t.ExecuteTemplate(&buf, name, data); err == nil {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("Content-Language", lang)
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Add("Vary", "Accept-Language")
+			writeBody(w, r, buf.Bytes(), gzipBody(buf.Bytes()))
+			return
+		}
+	}
+	log.Print(i18n.L("log.render_failed", name, err))
+	writeError(w, http.StatusInternalServerError, CodeInternal, tr(r, "error.render_failed"))
+}
+
+// HandleStatic serves the page's CSS and JavaScript. Requests for the
+// current version, as linked from the pages, may be cached for a year;
+// others must be revalidated with the ETag.
+func (s *Server) HandleStatic(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet, http.MethodHead) {
+		return
+	}
+	f, err := s.assets.file(r.PathValue("name"))
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	etag := `"` + f.version + `"`
+	if f.gzipped != nil && acceptsGzip(r) {
+		etag = `"` + f.version + `-gzip"`
+	}
+	w.Header().Set("ETag", etag)
+	if !s.assets.dev && r.URL.Query().Get("v") == f.version {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	if matchesETag(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", f.contentType)
+	writeBody(w, r, f.content, f.gzipped)
+}
+
+// matchesETag reports whether an If-None-Match header lists etag
+func matchesETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
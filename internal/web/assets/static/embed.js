@@ -0,0 +1,47 @@
+// Messages of the page's language, filled in by the page template
+const messages = window.messages || {};
+
+// t returns the message with the key, replacing %s and %d with args in order
+function t(key, ...args) {
+    let i = 0;
+    return (messages[key] || key).replace(/%[sd]/g, () => args[i++]);
+}
+
+const token = new URLSearchParams(location.hash.slice(1)).get('token');
+const headers = token ? { 'Authorization': 'Bearer ' + token } : {};
+
+function update() {
+    fetch('/api/v1/stats', { headers: headers })
+        .then(response => {
+            if (!response.ok) throw new Error(response.status === 401 ? t('js.token_required') : t('js.error_status', response.status));
+            return response.json();
+        })
+        .then(data => {
+            document.getElementById('error').textContent = '';
+            document.getElementById('in-hand').textContent = data.in_hand;
+            document.getElementById('in-air').textContent = data.in_air;
+            document.getElementById('points').textContent = data.score.points;
+            document.getElementById('time').textContent = t('js.seconds_short', data.time_elapsed);
+            const total = data.total_time * 60;
+            document.getElementById('progress').style.width = (total ? Math.min(100, data.time_elapsed / total * 100) : 0) + '%';
+
+            const container = document.getElementById('balls');
+            container.replaceChildren(...(data.balls || []).map(ball => {
+                const el = document.createElement('div');
+                el.className = 'ball ' + ball.status + ' ' + (ball.type || 'ball');
+                if (ball.color && ball.status !== 'dropped') {
+                    el.style.background = ball.color;
+                    el.style.borderColor = ball.color;
+                }
+                el.textContent = ball.id;
+                el.title = (ball.name || '') + ' ' + ball.id;
+                el.setAttribute('role', 'img');
+                el.setAttribute('aria-label', el.title.trim() + ', ' + t('js.status_' + ball.status));
+                return el;
+            }));
+        })
+        .catch(err => { document.getElementById('error').textContent = err.message; });
+}
+
+update();
+setInterval(update, Number(document.body.dataset.refresh));
@@ -0,0 +1,551 @@
+// Messages of the page's language, filled in by the page template
+const messages = window.messages || {};
+
+// t returns the message with the key, replacing %s and %d with args in order
+function t(key, ...args) {
+    let i = 0;
+    return (messages[key] || key).replace(/%[sd]/g, () => args[i++]);
+}
+
+// setLanguage remembers the chosen language and reloads the page in it
+function setLanguage(lang) {
+    document.cookie = 'juggler_lang=' + lang + '; path=/; max-age=31536000; SameSite=Lax';
+    location.reload();
+}
+
+// setTheme forces the light or dark theme, or follows the system if theme is empty
+function setTheme(theme) {
+    document.cookie = 'juggler_theme=' + theme + '; path=/; max-age=' + (theme ? 31536000 : 0) + '; SameSite=Lax';
+    if (theme) {
+        document.body.dataset.theme = theme;
+    } else {
+        delete document.body.dataset.theme;
+    }
+}
+
+let isRunning = false;
+let ballIds = [];
+let isManual = false;
+
+// Last seen ball statuses and counters, to announce throws and drops
+let lastStatuses = {};
+let lastCounts = null;
+const statusNames = { in_hand: t('js.status_in_hand'), in_flight: t('js.status_in_flight'), dropped: t('js.status_dropped') };
+
+// CSRF token of the login session, sent with requests that change state
+let csrfToken = '';
+const roleNames = { viewer: t('js.role_viewer'), operator: t('js.role_operator'), admin: t('js.role_admin') };
+
+function apiFetch(url, options = {}) {
+    const method = (options.method || 'GET').toUpperCase();
+    if (csrfToken && method !== 'GET') {
+        options.headers = Object.assign({}, options.headers, { 'X-CSRF-Token': csrfToken });
+    }
+    return fetch(url, options).then(response => {
+        if (response.status === 401) {
+            showLogin(null);
+        }
+        return response;
+    });
+}
+
+function showLogin(status) {
+    document.getElementById('login').style.display = 'block';
+    const loggedIn = status && status.name;
+    document.getElementById('login-form').style.display = loggedIn ? 'none' : 'block';
+    document.getElementById('login-user').style.display = loggedIn ? 'block' : 'none';
+    if (loggedIn) {
+        document.getElementById('login-identity').textContent =
+            '👤 ' + status.name + ' (' + (roleNames[status.role] || status.role) + ')';
+    }
+}
+
+function loadAuth() {
+    fetch('/api/v1/auth/status')
+        .then(response => response.json())
+        .then(status => {
+            csrfToken = status.csrf_token || '';
+            if (status.enabled) {
+                showLogin(status);
+            }
+        });
+}
+
+function login() {
+    fetch('/api/v1/auth/login', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({
+            name: document.getElementById('login-name').value,
+            password: document.getElementById('login-password').value
+        })
+    })
+    .then(response => response.json())
+    .then(data => {
+        if (data.error) {
+            showMessage(data.error.message, 'error');
+            return;
+        }
+        document.getElementById('login-password').value = '';
+        csrfToken = data.csrf_token || '';
+        showLogin(data);
+        updateStats();
+        loadPlayers();
+        loadLeaderboard();
+    });
+}
+
+function logout() {
+    apiFetch('/api/v1/auth/logout', { method: 'POST' })
+        .then(() => {
+            csrfToken = '';
+            showLogin(null);
+        });
+}
+
+function showMessage(text, type = 'success') {
+    const messageEl = document.getElementById('message');
+    messageEl.textContent = text;
+    messageEl.className = 'message ' + type;
+    messageEl.style.display = 'block';
+    setTimeout(() => {
+        messageEl.style.display = 'none';
+    }, 3000);
+}
+
+// Prop definitions sent with start, see BallDef in the API
+const propSets = {
+    balls: undefined,
+    clubs: [{ type: 'club', color: '#6f42c1', weight: 220 }],
+    rings: [{ type: 'ring', color: '#007bff', weight: 100, size: 32 }],
+    heavy: [{ name: t('js.heavy_ball'), color: '#343a40', weight: 250, size: 8 }],
+    mixed: [
+        { color: '#e74c3c' },
+        { type: 'club', color: '#6f42c1', weight: 220 },
+        { type: 'ring', color: '#007bff', weight: 100, size: 32 }
+    ]
+};
+const propNames = { ball: t('js.prop_ball'), club: t('js.prop_club'), ring: t('js.prop_ring') };
+
+function startJuggling() {
+    const balls = parseInt(document.getElementById('balls-input').value);
+    const time = parseInt(document.getElementById('time-input').value);
+    const skill = parseInt(document.getElementById('skill-input').value) || 0;
+    
+    if (balls < 1 || time < 1) {
+        showMessage(t('js.invalid_settings'), 'error');
+        return;
+    }
+    
+    apiFetch('/api/v1/start', {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json'
+        },
+        body: JSON.stringify({
+            total_balls: balls,
+            time_minutes: time,
+            manual: document.getElementById('manual-input').checked,
+            player: document.getElementById('player-input').value || undefined,
+            balls: propSets[document.getElementById('props-input').value],
+            performer: skill > 0 ? { skill: skill / 100 } : undefined,
+            passing: document.getElementById('passing-input').value.trim() || undefined
+        })
+    })
+    .then(response => response.json())
+    .then(data => {
+        if (data.status === 'started') {
+            isRunning = true;
+            document.getElementById('start-btn').disabled = true;
+            document.getElementById('stop-btn').disabled = false;
+            document.getElementById('balls-input').disabled = true;
+            document.getElementById('time-input').disabled = true;
+            showMessage(data.message, 'success');
+        } else if (data.error) {
+            showMessage(data.error.message, 'error');
+        }
+    })
+    .catch(error => {
+        showMessage(t('js.start_failed', error), 'error');
+    });
+}
+
+function stopJuggling() {
+    apiFetch('/api/v1/stop', {
+        method: 'POST'
+    })
+    .then(response => response.json())
+    .then(data => {
+        if (data.status === 'stopped') {
+            isRunning = false;
+            document.getElementById('start-btn').disabled = false;
+            document.getElementById('stop-btn').disabled = true;
+            document.getElementById('balls-input').disabled = false;
+            document.getElementById('time-input').disabled = false;
+            showMessage(data.message, 'success');
+        }
+    })
+    .catch(error => {
+        showMessage(t('js.stop_failed', error), 'error');
+    });
+}
+
+function loadPlayers(selected) {
+    apiFetch('/api/v1/players')
+    .then(response => response.json())
+    .then(players => {
+        const select = document.getElementById('player-input');
+        selected = selected || select.value;
+        select.innerHTML = '';
+        const none = document.createElement('option');
+        none.value = '';
+        none.textContent = t('js.no_player');
+        select.appendChild(none);
+        players.forEach(p => {
+            const option = document.createElement('option');
+            option.value = p.name;
+            option.textContent = p.name;
+            select.appendChild(option);
+        });
+        select.value = selected;
+    });
+}
+
+function addPlayer() {
+    const input = document.getElementById('new-player-input');
+    apiFetch('/api/v1/players', {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json'
+        },
+        body: JSON.stringify({ name: input.value })
+    })
+    .then(response => response.json())
+    .then(data => {
+        if (data.error) {
+            showMessage(data.error.message, 'error');
+            return;
+        }
+        input.value = '';
+        loadPlayers(data.name);
+        showMessage(t('js.player_created', data.name), 'success');
+    })
+    .catch(error => {
+        showMessage(t('js.player_failed', error), 'error');
+    });
+}
+
+function loadLeaderboard() {
+    const metric = document.getElementById('leaderboard-metric').value;
+    const balls = parseInt(document.getElementById('leaderboard-balls').value) || 0;
+    apiFetch('/api/v1/leaderboard?metric=' + metric + '&balls=' + balls)
+    .then(response => response.json())
+    .then(data => {
+        const rows = document.getElementById('leaderboard-rows');
+        rows.innerHTML = '';
+        (data.entries || []).forEach(e => {
+            const row = document.createElement('tr');
+            [e.rank, e.player, e.balls, Number.isInteger(e.value) ? e.value : e.value.toFixed(1),
+                new Date(e.start_time).toLocaleString()].forEach(value => {
+                const cell = document.createElement('td');
+                cell.textContent = value;
+                row.appendChild(cell);
+            });
+            rows.appendChild(row);
+        });
+    });
+}
+
+function throwBall(ballId) {
+    const body = { height: parseInt(document.getElementById('height-input').value) || 0 };
+    if (ballId) {
+        body.ball_id = ballId;
+    }
+    
+    apiFetch('/api/v1/throw', {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json'
+        },
+        body: JSON.stringify(body)
+    })
+    .then(response => response.json())
+    .then(data => {
+        if (data.error) {
+            showMessage(data.error.message, 'error');
+            return;
+        }
+        updateStats();
+    })
+    .catch(error => {
+        showMessage(t('js.throw_failed', error), 'error');
+    });
+}
+
+document.addEventListener('keydown', event => {
+    const tag = event.target.tagName;
+    if (!isManual || !isRunning || tag === 'INPUT' || tag === 'SELECT' || tag === 'TEXTAREA') {
+        return;
+    }
+    if (event.key === ' ') {
+        // Space on a focused button or link presses it instead
+        if (tag === 'BUTTON' || tag === 'A') {
+            return;
+        }
+        event.preventDefault();
+        throwBall(0);
+    } else if (event.key >= '1' && event.key <= '9') {
+        throwBall(parseInt(event.key));
+    }
+});
+
+function addBall() {
+    apiFetch('/api/v1/balls', { method: 'POST' })
+    .then(response => response.json())
+    .then(data => {
+        if (data.error) {
+            showMessage(data.error.message, 'error');
+            return;
+        }
+        showMessage(data.message, 'success');
+        updateStats();
+    })
+    .catch(error => {
+        showMessage(t('js.add_ball_failed', error), 'error');
+    });
+}
+
+function removeBall() {
+    // Remove the newest ball that is not already on its way out
+    const id = ballIds.filter(id => !document.getElementById('ball-' + id).classList.contains('ball-removing')).pop();
+    if (id === undefined) {
+        showMessage(t('js.no_balls'), 'error');
+        return;
+    }
+    
+    apiFetch('/api/v1/balls/' + id, { method: 'DELETE' })
+    .then(response => response.json())
+    .then(data => {
+        if (data.error) {
+            showMessage(data.error.message, 'error');
+            return;
+        }
+        showMessage(data.message, 'success');
+        updateStats();
+    })
+    .catch(error => {
+        showMessage(t('js.remove_ball_failed', error), 'error');
+    });
+}
+
+function updateStats() {
+    apiFetch('/api/v1/stats')
+        .then(response => response.json())
+        .then(data => {
+            document.getElementById('in-hand').textContent = data.in_hand;
+            document.getElementById('in-air').textContent = data.in_air;
+            document.getElementById('total-balls').textContent = data.total_balls;
+            document.getElementById('total-time').textContent = data.total_time;
+            document.getElementById('time').textContent = t('js.time', data.time_elapsed);
+            
+            document.getElementById('points').textContent = data.score.points;
+            document.getElementById('streak').textContent = data.score.streak;
+            document.getElementById('longest-streak').textContent = data.score.longest_streak;
+            document.getElementById('catches-per-minute').textContent = data.score.catches_per_minute.toFixed(1);
+            document.getElementById('drops').textContent = data.score.drops;
+            
+            const achievements = document.getElementById('achievements');
+            if (achievements.children.length !== data.score.achievements.length) {
+                achievements.innerHTML = '';
+                data.score.achievements.forEach(a => {
+                    const badge = document.createElement('span');
+                    badge.className = 'achievement';
+                    badge.textContent = '🏆 ' + a.name;
+                    badge.title = a.description;
+                    achievements.appendChild(badge);
+                });
+            }
+            
+            const performer = document.getElementById('performer');
+            performer.style.display = data.performer ? 'block' : 'none';
+            if (data.performer) {
+                const percent = value => Math.round(value * 100);
+                document.getElementById('stamina').style.width = percent(data.performer.stamina) + '%';
+                document.getElementById('stamina-bar').setAttribute('aria-valuenow', percent(data.performer.stamina));
+                document.getElementById('stamina-value').textContent = percent(data.performer.stamina);
+                document.getElementById('form').style.width = percent(data.performer.form) + '%';
+                document.getElementById('form-bar').setAttribute('aria-valuenow', percent(data.performer.form));
+                document.getElementById('form-value').textContent = percent(data.performer.form);
+                document.getElementById('miss-chance').textContent = (data.performer.miss_chance * 100).toFixed(1);
+            }
+            
+            const peer = document.getElementById('peer');
+            peer.style.display = data.peer ? 'block' : 'none';
+            if (data.peer) {
+                const p = data.peer;
+                peer.className = 'message ' + (p.connected ? 'success' : 'error');
+                peer.textContent = p.connected
+                    ? t('js.peer_connected', p.peer, p.address, p.clock_offset_ms.toFixed(1), p.in_transit, p.passed, p.received, p.lost)
+                    : t('js.peer_disconnected', p.name, p.lost);
+            }
+            
+            const troupe = document.getElementById('troupe');
+            troupe.style.display = data.troupe ? 'block' : 'none';
+            if (data.troupe) {
+                document.getElementById('passing-pattern').textContent = data.passing;
+                const members = document.getElementById('troupe-members');
+                members.innerHTML = '';
+                data.troupe.forEach(m => {
+                    const card = document.createElement('div');
+                    card.className = 'stat-card';
+                    card.innerHTML = '<b>' + t('js.troupe_member', m.performer) + '</b><br>' +
+                        t('js.troupe_in_hand', m.in_hand, m.incoming) + '<br>' +
+                        t('js.troupe_throws', m.throws, m.passes_made, m.passes_received) + '<br>' +
+                        t('js.troupe_catches', m.catches, m.drops);
+                    members.appendChild(card);
+                });
+            }
+            
+            isManual = data.manual;
+            document.getElementById('manual-hint').style.display = data.manual && data.is_running ? 'block' : 'none';
+            
+            // Update progress bar
+            const progress = data.total_time > 0 ? (data.time_elapsed / (data.total_time * 60)) * 100 : 0;
+            document.getElementById('progress').style.width = Math.min(progress, 100) + '%';
+            document.getElementById('progress-bar').setAttribute('aria-valuenow', Math.round(Math.min(progress, 100)));
+            
+            // Update status
+            const statusElement = document.getElementById('status');
+            if (data.is_running) {
+                statusElement.className = 'status running';
+                statusElement.innerHTML = '<span>' + t('js.running') + '</span>';
+                isRunning = true;
+                document.getElementById('start-btn').disabled = true;
+                document.getElementById('stop-btn').disabled = false;
+                document.getElementById('balls-input').disabled = true;
+                document.getElementById('time-input').disabled = true;
+            } else if (data.is_finished) {
+                statusElement.className = 'status finished';
+                statusElement.innerHTML = '<span>' + t('js.finished') + '</span>';
+                isRunning = false;
+                document.getElementById('start-btn').disabled = false;
+                document.getElementById('stop-btn').disabled = true;
+                document.getElementById('balls-input').disabled = false;
+                document.getElementById('time-input').disabled = false;
+            } else {
+                statusElement.className = 'status stopped';
+                statusElement.innerHTML = '<span>' + t('js.stopped') + '</span>';
+                isRunning = false;
+                document.getElementById('start-btn').disabled = false;
+                document.getElementById('stop-btn').disabled = true;
+                document.getElementById('balls-input').disabled = false;
+                document.getElementById('time-input').disabled = false;
+            }
+            
+            // Update balls - maintain consistent layout
+            const ballsContainer = document.getElementById('balls');
+            
+            // Create a map of balls by ID for quick lookup
+            const ballsById = {};
+            data.balls.forEach(ball => {
+                ballsById[ball.id] = ball;
+            });
+            
+            // Ball IDs stay stable when balls are added or removed,
+            // so recreate the container only when the set of IDs changes
+            const ids = data.balls.map(ball => ball.id).sort((a, b) => a - b);
+            if (ids.join(',') !== ballIds.join(',')) {
+                ballIds = ids;
+                ballsContainer.innerHTML = '';
+                ids.forEach(id => {
+                    // Buttons, so that balls can be thrown by tapping or from the keyboard in manual mode
+                    const ballElement = document.createElement('button');
+                    ballElement.type = 'button';
+                    ballElement.className = 'ball';
+                    ballElement.id = 'ball-' + id;
+                    ballElement.onclick = () => throwBall(id);
+                    ballsContainer.appendChild(ballElement);
+                });
+            }
+            
+            // Update each ball element in place
+            ids.forEach(id => {
+                const ballElement = document.getElementById('ball-' + id);
+                const ball = ballsById[id];
+                const type = ball.type || 'ball';
+                let name = (ball.name || propNames[type]) + ' ' + ball.id;
+                if (ball.performer) {
+                    name += ball.pass_from ? ' (' + ball.pass_from + ' → ' + ball.performer + ')' : ' · ' + t('js.performer_short', ball.performer);
+                }
+                
+                // Each state has an icon and outline besides its color, see home.css
+                if (ball.status === 'in_hand') {
+                    ballElement.className = 'ball ball-in-hand';
+                    ballElement.textContent = name;
+                } else if (ball.status === 'in_flight') {
+                    ballElement.className = 'ball ball-in-flight';
+                    ballElement.textContent = name + ' (' + ball.elapsed + '/' + ball.flight_time + 's)';
+                } else {
+                    ballElement.className = 'ball ball-dropped';
+                    ballElement.textContent = name;
+                }
+                const throwable = isManual && isRunning && ball.status === 'in_hand' && !ball.removing;
+                ballElement.disabled = !throwable;
+                ballElement.setAttribute('aria-label', throwable
+                    ? t('js.throw_ball', name)
+                    : ballElement.textContent + ', ' + (statusNames[ball.status] || ball.status));
+                ballElement.classList.add('ball-' + type);
+                if (ball.pass_from) {
+                    ballElement.classList.add('ball-pass');
+                }
+                ballElement.style.borderColor = ball.color || '';
+                ballElement.title = t('js.ball_title', propNames[type], ball.weight || 120, ball.size || 7);
+                if (ball.removing) {
+                    ballElement.classList.add('ball-removing');
+                }
+            });
+            
+            announce(data);
+        })
+        .catch(error => {
+            console.error(t('js.stats_failed'), error);
+        });
+}
+
+// announce tells screen readers about the throws, catches and drops since
+// the last update: drops at once, the rest when the reader is idle
+function announce(data) {
+    const counts = { thrown: data.score.catches + data.score.drops + data.in_air, caught: data.score.catches };
+    const dropped = data.balls
+        .filter(ball => ball.status === 'dropped' && lastStatuses[ball.id] && lastStatuses[ball.id] !== 'dropped')
+        .map(ball => (ball.name || propNames[ball.type || 'ball']) + ' ' + ball.id);
+    
+    if (data.is_running && lastCounts) {
+        const thrown = counts.thrown - lastCounts.thrown;
+        const caught = counts.caught - lastCounts.caught;
+        if (thrown > 0 || caught > 0) {
+            document.getElementById('announcer').textContent = t('js.announce_throws', Math.max(thrown, 0), Math.max(caught, 0));
+        }
+        if (dropped.length > 0) {
+            document.getElementById('drop-announcer').textContent = t('js.announce_drops', dropped.join(', '));
+        }
+    }
+    
+    lastCounts = counts;
+    lastStatuses = {};
+    data.balls.forEach(ball => {
+        lastStatuses[ball.id] = ball.status;
+    });
+}
+
+loadAuth();
+
+// Update stats every second
+setInterval(updateStats, 1000);
+// Initial update but don't start juggling automatically
+updateStats();
+
+// Finished runs reach the leaderboard a few seconds after a session ends
+setInterval(loadLeaderboard, 5000);
+loadPlayers();
+loadLeaderboard();
@@ -0,0 +1,157 @@
+// Sound cues for throws, catches and drops, and a metronome on the throw
+// beat. Events come from the engine's event stream and are played at their
+// engine timestamps, so that the sounds keep the pattern's rhythm however
+// they arrive.
+
+// Seconds of buffer between an event and its sound, absorbing network jitter
+const soundLatency = 0.15;
+
+// Tones by event type: frequency in Hz, duration in seconds, wave and
+// frequency at the end for a slide
+const tones = {
+    throw: { freq: 660, duration: 0.08, wave: 'sine' },
+    catch: { freq: 440, duration: 0.1, wave: 'triangle' },
+    drop: { freq: 220, end: 80, duration: 0.35, wave: 'sawtooth' },
+    beat: { freq: 1200, duration: 0.03, wave: 'square', volume: 0.08 }
+};
+
+let audio = null;
+let eventSource = null;
+let clockOffset = null; // audio clock minus engine clock, in seconds
+let soundSession = 0;
+let metronome = false;
+let beatPeriod = 0; // seconds between throws, from the engine options
+let nextBeat = null; // audio time of the next metronome click
+
+// playTone plays the tone of a kind at an audio time
+function playTone(kind, at) {
+    const tone = tones[kind];
+    const osc = audio.createOscillator();
+    const gain = audio.createGain();
+    osc.type = tone.wave;
+    osc.frequency.setValueAtTime(tone.freq, at);
+    if (tone.end) {
+        osc.frequency.exponentialRampToValueAtTime(tone.end, at + tone.duration);
+    }
+    gain.gain.setValueAtTime(tone.volume || 0.2, at);
+    gain.gain.exponentialRampToValueAtTime(0.001, at + tone.duration);
+    osc.connect(gain).connect(audio.destination);
+    osc.start(at);
+    osc.stop(at + tone.duration);
+}
+
+// engineSeconds converts an event time to seconds; JavaScript dates keep
+// milliseconds, the engine's times carry nanoseconds
+function engineSeconds(time) {
+    return Date.parse(time.replace(/(\.\d{3})\d+/, '$1')) / 1000;
+}
+
+// playEvent schedules the sound of an engine event. The smallest delay seen
+// between an event and its arrival maps engine time onto the audio clock.
+function playEvent(e) {
+    const engineTime = engineSeconds(e.time);
+    const offset = audio.currentTime - engineTime;
+    if (clockOffset === null || offset < clockOffset) {
+        clockOffset = offset;
+    }
+    const due = engineTime + clockOffset + soundLatency;
+    if (due < audio.currentTime - 0.5) {
+        // Missed while reconnecting; too late to keep the rhythm
+        return;
+    }
+    const at = Math.max(due, audio.currentTime);
+    if (tones[e.type]) {
+        playTone(e.type, at);
+    }
+    if (e.type === 'throw' && nextBeat === null) {
+        // The metronome keeps the beat of the session's first throw
+        nextBeat = at;
+    }
+}
+
+// loadBeat reads the engine's throw interval
+function loadBeat() {
+    fetch('/api/v1/options')
+        .then(response => response.json())
+        .then(options => {
+            beatPeriod = (options.throw_interval_ms || 0) / 1000;
+        });
+}
+
+// scheduleBeats schedules the metronome clicks of the next moment while a
+// session runs; the Web Audio clock keeps them steady between calls
+function scheduleBeats() {
+    if (!audio || !metronome || !isRunning || nextBeat === null || beatPeriod <= 0) {
+        return;
+    }
+    if (nextBeat < audio.currentTime) {
+        // Catch up after the page was in the background
+        nextBeat += Math.ceil((audio.currentTime - nextBeat) / beatPeriod) * beatPeriod;
+    }
+    while (nextBeat < audio.currentTime + 0.2) {
+        playTone('beat', nextBeat);
+        nextBeat += beatPeriod;
+    }
+}
+
+// setSound turns the sound cues on or off and remembers the choice
+function setSound(on) {
+    localStorage.setItem('juggler_sound', on ? '1' : '');
+    document.getElementById('metronome-input').disabled = !on;
+    if (!on) {
+        if (eventSource) {
+            eventSource.close();
+            eventSource = null;
+        }
+        return;
+    }
+
+    if (!audio) {
+        audio = new AudioContext();
+    }
+    audio.resume();
+    if (!eventSource) {
+        eventSource = new EventSource('/api/v1/events');
+        eventSource.onopen = () => {
+            // Delays change across connections
+            clockOffset = null;
+        };
+        eventSource.onmessage = message => {
+            const e = JSON.parse(message.data);
+            if (e.session !== soundSession) {
+                soundSession = e.session;
+                nextBeat = null;
+                loadBeat();
+            }
+            playEvent(e);
+        };
+    }
+    loadBeat();
+}
+
+// setMetronome turns the metronome on or off and remembers the choice
+function setMetronome(on) {
+    localStorage.setItem('juggler_metronome', on ? '1' : '');
+    metronome = on;
+}
+
+setInterval(scheduleBeats, 50);
+
+// Browsers start audio only after the user interacts with the page, so
+// remembered choices take effect on the first click or key press
+const soundInput = document.getElementById('sound-input');
+const metronomeInput = document.getElementById('metronome-input');
+soundInput.checked = localStorage.getItem('juggler_sound') === '1';
+metronomeInput.checked = metronome = localStorage.getItem('juggler_metronome') === '1';
+metronomeInput.disabled = !soundInput.checked;
+if (soundInput.checked) {
+    const resume = () => {
+        document.removeEventListener('pointerdown', resume);
+        document.removeEventListener('keydown', resume);
+        if (soundInput.checked) {
+            setSound(true);
+        }
+    };
+    document.addEventListener('pointerdown', resume);
+    document.addEventListener('keydown', resume);
+}
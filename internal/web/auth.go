@@ -0,0 +1,175 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"juggler/internal/auth"
+)
+
+// SessionCookie names the cookie holding the login session of the page
+const SessionCookie = "juggler_session"
+
+// CSRFHeader names the header that must carry the login session's CSRF
+// token on requests that change state
+const CSRFHeader = "X-CSRF-Token"
+
+// LoginRequest represents a request to log in on the page
+type LoginRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// AuthStatus describes whether authentication is on and who the caller is
+type AuthStatus struct {
+	Enabled   bool      `json:"enabled"`
+	Name      string    `json:"name,omitempty"`
+	Role      auth.Role `json:"role,omitempty"`
+	CSRFToken string    `json:"csrf_token,omitempty"` // set for page logins
+}
+
+// SetAuth requires callers of the API to authenticate; nil, the default,
+// lets everyone do everything
+func (s *Server) SetAuth(a *auth.Authenticator) {
+	s.auth = a
+}
+
+// identify returns the caller's identity and, for a page login, its
+// session. A bearer token takes precedence over the session cookie.
+func (s *Server) identify(r *http.Request) (auth.Identity, *auth.Session, bool) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			return auth.Identity{}, nil, false
+		}
+		id, ok := s.auth.CheckToken(token)
+		return id, nil, ok
+	}
+
+	cookie, err := r.Cookie(SessionCookie)
+	if err != nil {
+		return auth.Identity{}, nil, false
+	}
+	session, err := s.auth.Session(cookie.Value)
+	if err != nil {
+		return auth.Identity{}, nil, false
+	}
+	return session.Identity, &session, true
+}
+
+// requireRole wraps a handler so that it only serves callers with the
+// given role, and page logins only with their CSRF token when the
+// request changes state
+func (s *Server) requireRole(role auth.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.auth == nil || role == "" {
+			next(w, r)
+			return
+		}
+
+		id, session, ok := s.identify(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="juggler"`)
+			writeError(w, http.StatusUnauthorized, CodeUnauthorized, tr(r, "error.auth_required"))
+			return
+		}
+		if !id.Role.Allows(role) {
+			writeError(w, http.StatusForbidden, CodeForbidden, tr(r, "error.requires_role", role))
+			return
+		}
+		if session != nil && !safeMethod(r.Method) && !session.ValidCSRF(r.Header.Get(CSRFHeader)) {
+			writeError(w, http.StatusForbidden, CodeCSRFFailed, tr(r, "error.csrf_failed"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// safeMethod reports whether a request with the method cannot change state
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// HandleLogin checks a username and password and starts a page session
+func (s *Server) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	if s.auth == nil {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, tr(r, "error.auth_disabled"))
+		return
+	}
+
+	var req LoginRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	session, err := s.auth.Login(req.Name, req.Password)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, tr(r, "error.bad_credentials"))
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookie,
+		Value:    session.ID,
+		Path:     "/",
+		Expires:  session.Expires,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+	writeJSON(w, http.StatusOK, AuthStatus{
+		Enabled:   true,
+		Name:      session.Identity.Name,
+		Role:      session.Identity.Role,
+		CSRFToken: session.CSRF,
+	})
+}
+
+// HandleLogout ends the page session
+func (s *Server) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	if s.auth != nil {
+		if _, session, ok := s.identify(r); ok && session != nil {
+			if !session.ValidCSRF(r.Header.Get(CSRFHeader)) {
+				writeError(w, http.StatusForbidden, CodeCSRFFailed, tr(r, "error.csrf_failed"))
+				return
+			}
+			s.auth.Logout(session.ID)
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookie,
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	writeJSON(w, http.StatusOK, StatusResponse{Status: "logged_out", Message: tr(r, "status.logged_out")})
+}
+
+// HandleAuthStatus tells the page whether it needs to log in and hands a
+// logged in page its CSRF token
+func (s *Server) HandleAuthStatus(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	status := AuthStatus{Enabled: s.auth != nil}
+	if s.auth != nil {
+		if id, session, ok := s.identify(r); ok {
+			status.Name, status.Role = id.Name, id.Role
+			if session != nil {
+				status.CSRFToken = session.CSRF
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"juggler/internal/juggler"
+)
+
+// BallResponse represents the result of adding or removing a ball
+type BallResponse struct {
+	ID         int    `json:"id"`
+	Status     string `json:"status"` // "added", "removed", or "removing" while the ball is in flight
+	Message    string `json:"message"`
+	TotalBalls int    `json:"total_balls"`
+}
+
+// HandleAddBall adds a ball to the session; while juggling it is thrown on the next throw
+func (s *Server) HandleAddBall(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	id := s.juggler.AddBall()
+
+	w.Header().Set("Location", fmt.Sprintf("%s/balls/%d", APIPrefix, id))
+	writeJSON(w, http.StatusCreated, BallResponse{
+		ID:         id,
+		Status:     "added",
+		Message:    tr(r, "status.ball_added", id),
+		TotalBalls: s.juggler.GetTotalBalls(),
+	})
+}
+
+// HandleRemoveBall removes a ball from the session. A ball in flight is
+// removed when it lands, which is answered with 202.
+func (s *Server) HandleRemoveBall(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, tr(r, "error.invalid_ball_id"),
+			FieldError{Field: "id", Message: tr(r, "field.positive_integer")})
+		return
+	}
+
+	removed, err := s.juggler.RemoveBall(id)
+	if errors.Is(err, juggler.ErrUnknownBall) {
+		writeError(w, http.StatusNotFound, CodeNotFound, tr(r, "error.ball_not_found", id))
+		return
+	}
+
+	if !removed {
+		writeJSON(w, http.StatusAccepted, BallResponse{
+			ID:         id,
+			Status:     "removing",
+			Message:    tr(r, "status.ball_removing", id),
+			TotalBalls: s.juggler.GetTotalBalls(),
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, BallResponse{
+		ID:         id,
+		Status:     "removed",
+		Message:    tr(r, "status.ball_removed", id),
+		TotalBalls: s.juggler.GetTotalBalls(),
+	})
+}
@@ -0,0 +1,86 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions lets pages on other origins call the API
+type CORSOptions struct {
+	AllowedOrigins []string // e.g. "https://portal.example.com"; "*" allows any
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposedHeaders []string // response headers scripts may read
+	MaxAge         time.Duration
+}
+
+// DefaultCORSOptions allows the given origins to use the API with tokens
+func DefaultCORSOptions(origins ...string) CORSOptions {
+	return CORSOptions{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowedHeaders: []string{"Content-Type", "Authorization", IdempotencyKeyHeader, CSRFHeader},
+		ExposedHeaders: []string{"Location", "Retry-After", "Idempotent-Replayed"},
+		MaxAge:         10 * time.Minute,
+	}
+}
+
+// SetCORS allows cross-origin requests and framing of /embed from the
+// given origins; call it before Handler or Start
+func (s *Server) SetCORS(o CORSOptions) {
+	s.cors = o
+}
+
+// allowsOrigin reports whether requests from origin are allowed
+func (o CORSOptions) allowsOrigin(origin string) bool {
+	return slices.Contains(o.AllowedOrigins, "*") || slices.Contains(o.AllowedOrigins, origin)
+}
+
+// CORS adds the CORS headers to responses to allowed origins and answers
+// their preflight requests. Preflights from other origins get 403;
+// browsers then refuse to send the request. Credentials are not allowed,
+// so cross-origin callers authenticate with tokens rather than cookies.
+func CORS(o CORSOptions, next http.Handler) http.Handler {
+	if len(o.AllowedOrigins) == 0 {
+		return next
+	}
+
+	methods := strings.Join(o.AllowedMethods, ", ")
+	headers := strings.Join(o.AllowedHeaders, ", ")
+	exposed := strings.Join(o.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(o.MaxAge.Seconds()))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		w.Header().Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		if origin == "" || !o.allowsOrigin(origin) {
+			if preflight {
+				writeError(w, http.StatusForbidden, CodeForbidden, tr(r, "error.origin_not_allowed"))
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if !preflight {
+			if exposed != "" {
+				w.Header().Set("Access-Control-Expose-Headers", exposed)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		w.Header().Set("Access-Control-Allow-Methods", methods)
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+		w.Header().Set("Access-Control-Max-Age", maxAge)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Limits of the embed view's refresh interval in milliseconds
+const (
+	minEmbedRefresh     = 250
+	maxEmbedRefresh     = 60000
+	defaultEmbedRefresh = 1000
+)
+
+// embedThemes are the colors of the embed view's themes: background,
+// text and accent
+var embedThemes = map[string][3]string{
+	"light": {"#ffffff", "#333333", "#28a745"},
+	"dark":  {"#1e1e1e", "#e0e0e0", "#20c997"},
+}
+
+// hexColorPattern matches the colors the embed view accepts, with or
+// without the leading #, which has to be escaped as %23 in a URL
+var hexColorPattern = regexp.MustCompile(`^#?([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// embedView is what the embed template renders
+type embedView struct {
+	Theme      string
+	Background string
+	Foreground string
+	Accent     string
+	Refresh    int // milliseconds between stats polls
+	Title      string
+}
+
+// parseEmbedView reads the embed view's query parameters
+func parseEmbedView(r *http.Request) (embedView, []FieldError) {
+	q := r.URL.Query()
+	var details []FieldError
+
+	v := embedView{Theme: "light", Refresh: defaultEmbedRefresh, Title: q.Get("title")}
+	if theme := q.Get("theme"); theme != "" {
+		if _, ok := embedThemes[theme]; !ok {
+			details = append(details, FieldError{Field: "theme", Message: tr(r, "field.embed_theme")})
+		} else {
+			v.Theme = theme
+		}
+	}
+	colors := embedThemes[v.Theme]
+	v.Background, v.Foreground, v.Accent = colors[0], colors[1], colors[2]
+
+	for _, c := range []struct {
+		field string
+		dst   *string
+	}{{"bg", &v.Background}, {"fg", &v.Foreground}, {"accent", &v.Accent}} {
+		value := q.Get(c.field)
+		if value == "" {
+			continue
+		}
+		if !hexColorPattern.MatchString(value) {
+			details = append(details, FieldError{Field: c.field, Message: tr(r, "field.hex_color")})
+			continue
+		}
+		*c.dst = "#" + strings.TrimPrefix(value, "#")
+	}
+
+	if refresh := q.Get("refresh"); refresh != "" {
+		ms, err := strconv.Atoi(refresh)
+		if err != nil || ms < minEmbedRefresh || ms > maxEmbedRefresh {
+			details = append(details, FieldError{Field: "refresh", Message: tr(r, "field.embed_refresh", minEmbedRefresh, maxEmbedRefresh)})
+		} else {
+			v.Refresh = ms
+		}
+	}
+	if len([]rune(v.Title)) > 64 {
+		details = append(details, FieldError{Field: "title", Message: tr(r, "field.max_length", 64)})
+	}
+	return v, details
+}
+
+// frameAncestors returns the Content-Security-Policy letting the CORS
+// origins put the embed view in a frame
+func (s *Server) frameAncestors() string {
+	sources := []string{"'self'"}
+	for _, origin := range s.cors.AllowedOrigins {
+		if origin == "*" {
+			sources = []string{"*"}
+			break
+		}
+		sources = append(sources, origin)
+	}
+	return "frame-ancestors " + strings.Join(sources, " ")
+}
+
+// HandleEmbed serves a compact view of the juggler without controls, for
+// other pages to put in an iframe. With authentication on, the page reads
+// a viewer token from the URL fragment, e.g. /embed#token=..., as session
+// cookies are not sent to frames on other sites.
+func (s *Server) HandleEmbed(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	view, details := parseEmbedView(r)
+	if len(details) > 0 {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, tr(r, "error.invalid_embed"), details...)
+		return
+	}
+
+	w.Header().Set("Content-Security-Policy", s.frameAncestors())
+	s.renderPage(w, r, "embed.html", view)
+}
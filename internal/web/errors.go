@@ -0,0 +1,83 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Error codes used in ErrorBody.Code
+const (
+	CodeBadRequest       = "bad_request"
+	CodeInvalidBody      = "invalid_body"
+	CodeValidationFailed = "validation_failed"
+	CodeConflict         = "conflict"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeCSRFFailed       = "csrf_failed"
+	CodeBodyTooLarge     = "body_too_large"
+	CodeRateLimited      = "rate_limited"
+	CodeInternal         = "internal_error"
+	CodeTimeout          = "timeout"
+
+	CodeIdempotencyMismatch = "idempotency_mismatch"
+)
+
+// ErrorResponse is the envelope of every API error
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes what went wrong
+type ErrorBody struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError points at an invalid request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// writeError replies with the error envelope and the given status code
+func writeError(w http.ResponseWriter, status int, code, message string, details ...FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Error: ErrorBody{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+}
+
+// requireMethod replies 405 and returns false unless r uses one of methods
+func requireMethod(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, m := range methods {
+		if r.Method == m {
+			return true
+		}
+	}
+
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, tr(r, "error.method_not_allowed"))
+	return false
+}
+
+// methodNotAllowed returns a handler rejecting every request with 405,
+// used for paths whose allowed methods are routed elsewhere
+func methodNotAllowed(methods []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requireMethod(w, r, methods...)
+	}
+}
+
+// notFound replies 404 for unknown paths
+func notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, CodeNotFound, tr(r, "error.not_found"))
+}
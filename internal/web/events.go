@@ -0,0 +1,134 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"juggler/internal/juggler"
+)
+
+// eventsInterval is how often the event stream looks for new events
+const eventsInterval = 50 * time.Millisecond
+
+// eventsKeepAlive is how often an idle event stream sends a comment, so
+// that proxies do not close it
+const eventsKeepAlive = 15 * time.Second
+
+// EventsResponse is a page of the current session's events
+type EventsResponse struct {
+	Session int             `json:"session"`
+	Next    int             `json:"next"` // since for the following page
+	Events  []juggler.Event `json:"events"`
+}
+
+// EventMessage is an event sent on the event stream
+type EventMessage struct {
+	juggler.Event
+	Session int `json:"session"`
+	Index   int `json:"index"` // position of the event in its session
+}
+
+// HandleEvents serves the current session's events from index ?since= on.
+// Clients accepting text/event-stream, such as EventSource, instead get
+// each event as it happens, with its engine timestamp: new events only
+// unless since or Last-Event-ID says otherwise, and every session started
+// later from its first event.
+func (s *Server) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query().Get("since")
+	since, err := optionalInt(query, 0)
+	if err != nil || since < 0 {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, tr(r, "error.invalid_events_query"),
+			FieldError{Field: "since", Message: tr(r, "field.non_negative_integer")})
+		return
+	}
+
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		session, events := s.juggler.GetEventsSince(since)
+		if events == nil {
+			events = []juggler.Event{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EventsResponse{Session: session, Next: since + len(events), Events: events})
+		return
+	}
+
+	session, events := s.juggler.GetEventsSince(0)
+	if query == "" {
+		since = len(events)
+	}
+	if last, index, ok := parseEventID(r.Header.Get("Last-Event-ID")); ok && last == session {
+		since = index + 1
+	}
+	s.streamEvents(w, r, session, since)
+}
+
+// parseEventID parses the ID of a streamed event, "session:index"
+func parseEventID(id string) (session, index int, ok bool) {
+	a, b, found := strings.Cut(id, ":")
+	if !found {
+		return 0, 0, false
+	}
+	session, err1 := strconv.Atoi(a)
+	index, err2 := strconv.Atoi(b)
+	return session, index, err1 == nil && err2 == nil
+}
+
+// streamEvents sends the events of session from index next on as
+// server-sent events until the client goes away
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, session, next int) {
+	rc := http.NewResponseController(w)
+	// The stream outlives the server's write timeout
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(eventsInterval)
+	defer ticker.Stop()
+
+	lastWrite := time.Now()
+	for {
+		current, events := s.juggler.GetEventsSince(next)
+		if current != session {
+			// A new session has started; follow it from its first event
+			session, next = current, 0
+			continue
+		}
+
+		for i, e := range events {
+			data, _ := json.Marshal(EventMessage{Event: e, Session: session, Index: next + i})
+			fmt.Fprintf(w, "id: %d:%d\ndata: %s\n\n", session, next+i, data)
+		}
+		next += len(events)
+
+		wrote := len(events) > 0
+		if !wrote && time.Since(lastWrite) >= eventsKeepAlive {
+			fmt.Fprint(w, ": keep-alive\n\n")
+			wrote = true
+		}
+		if wrote {
+			if err := rc.Flush(); err != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
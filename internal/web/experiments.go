@@ -0,0 +1,288 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"juggler/internal/batch"
+	"juggler/internal/i18n"
+)
+
+// maxExperiments is how many finished experiments are kept for download
+const maxExperiments = 20
+
+// Limits of a single experiment, so that one request cannot hold every
+// CPU for long
+const (
+	maxExperimentMinutes         = 60
+	maxExperimentRuns            = 1000
+	minExperimentThrowIntervalMS = 50
+	maxExperimentBalls           = 20    // balls of one configuration
+	maxExperimentSessions        = 10000 // configurations times runs
+)
+
+// experimentTimeout is how long an experiment may run; it stays under the
+// server's write timeout so that the client still gets the answer
+const experimentTimeout = time.Minute
+
+// ExperimentRequest represents the request to run a parameter sweep.
+// Omitted lists default to the engine's standard parameters.
+type ExperimentRequest struct {
+	Balls            []int               `json:"balls"`
+	FlightRanges     []batch.FlightRange `json:"flight_ranges"`
+	ThrowIntervalsMS []int               `json:"throw_intervals_ms"`
+	DropChances      []float64           `json:"drop_chances"`
+	Minutes          int                 `json:"minutes"`
+	Runs             int                 `json:"runs"`
+	Seed             int64               `json:"seed"`
+}
+
+// ExperimentResponse describes a finished experiment and where to download its results
+type ExperimentResponse struct {
+	ID             string            `json:"id"`
+	Configurations int               `json:"configurations"`
+	Elapsed        int64             `json:"elapsed_ms"`
+	Rows           []batch.Row       `json:"rows"`
+	Downloads      map[string]string `json:"downloads"`
+}
+
+// experimentStore keeps the most recent experiments in memory
+type experimentStore struct {
+	mu     sync.RWMutex
+	nextID int
+	order  []string
+	sweeps map[string]*batch.Sweep
+}
+
+// add stores a sweep, evicting the oldest one when the store is full, and returns its ID
+func (st *experimentStore) add(s *batch.Sweep) string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.sweeps == nil {
+		st.sweeps = make(map[string]*batch.Sweep)
+	}
+
+	st.nextID++
+	id := fmt.Sprintf("exp-%d", st.nextID)
+	st.sweeps[id] = s
+	st.order = append(st.order, id)
+
+	if len(st.order) > maxExperiments {
+		delete(st.sweeps, st.order[0])
+		st.order = st.order[1:]
+	}
+
+	return id
+}
+
+// get returns the sweep with the given ID
+func (st *experimentStore) get(id string) (*batch.Sweep, bool) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	s, ok := st.sweeps[id]
+	return s, ok
+}
+
+// validate checks the request against the per-experiment limits, with
+// messages in lang; the grid itself is checked by batch
+func (req ExperimentRequest) validate(lang string) []FieldError {
+	var details []FieldError
+	if req.Minutes < 0 || req.Minutes > maxExperimentMinutes {
+		details = append(details, FieldError{Field: "minutes", Message: i18n.T(lang, "field.between", 1, maxExperimentMinutes)})
+	}
+	if req.Runs < 0 || req.Runs > maxExperimentRuns {
+		details = append(details, FieldError{Field: "runs", Message: i18n.T(lang, "field.between", 1, maxExperimentRuns)})
+	}
+	for i, ms := range req.ThrowIntervalsMS {
+		if ms < minExperimentThrowIntervalMS {
+			details = append(details, FieldError{Field: fmt.Sprintf("throw_intervals_ms[%d]", i),
+				Message: i18n.T(lang, "field.at_least", minExperimentThrowIntervalMS)})
+		}
+	}
+	for i, n := range req.Balls {
+		if n < 1 || n > maxExperimentBalls {
+			details = append(details, FieldError{Field: fmt.Sprintf("balls[%d]", i),
+				Message: i18n.T(lang, "field.between", 1, maxExperimentBalls)})
+		}
+	}
+	if req.sessions() > maxExperimentSessions {
+		details = append(details, FieldError{Field: "sweep",
+			Message: i18n.T(lang, "field.experiment_sessions", maxExperimentSessions)})
+	}
+	return details
+}
+
+// sessions returns how many sessions the sweep would simulate, stopping
+// just past maxExperimentSessions so that huge lists cannot overflow
+func (req ExperimentRequest) sessions() int {
+	g := req.grid()
+	n := min(g.Runs, maxExperimentSessions+1)
+	for _, values := range []int{len(g.Balls), len(g.FlightRanges), len(g.ThrowIntervals), len(g.DropChances)} {
+		n = min(n*values, maxExperimentSessions+1)
+	}
+	return n
+}
+
+// grid converts the request into a sweep grid, filling in defaults
+func (req ExperimentRequest) grid() batch.Grid {
+	g := batch.Grid{
+		Balls:        req.Balls,
+		FlightRanges: req.FlightRanges,
+		DropChances:  req.DropChances,
+		Minutes:      req.Minutes,
+		Runs:         req.Runs,
+		Seed:         req.Seed,
+	}
+
+	if len(g.FlightRanges) == 0 {
+		g.FlightRanges = []batch.FlightRange{{Min: 5, Max: 10}}
+	}
+	for _, ms := range req.ThrowIntervalsMS {
+		g.ThrowIntervals = append(g.ThrowIntervals, time.Duration(ms)*time.Millisecond)
+	}
+	if len(g.ThrowIntervals) == 0 {
+		g.ThrowIntervals = []time.Duration{500 * time.Millisecond}
+	}
+	if len(g.DropChances) == 0 {
+		g.DropChances = []float64{0}
+	}
+	if g.Minutes == 0 {
+		g.Minutes = 2
+	}
+	if g.Runs == 0 {
+		g.Runs = 20
+	}
+
+	return g
+}
+
+// HandleExperiments runs a parameter sweep headlessly and stores the results
+// for download. The sweep stops when the client goes away or after
+// experimentTimeout.
+func (s *Server) HandleExperiments(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req ExperimentRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if details := req.validate(language(r)); len(details) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, CodeValidationFailed, tr(r, "error.invalid_experiment"), details...)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), experimentTimeout)
+	defer cancel()
+
+	sweep, err := batch.RunSweepContext(ctx, req.grid())
+	if errors.Is(err, context.DeadlineExceeded) {
+		writeError(w, http.StatusServiceUnavailable, CodeTimeout, tr(r, "error.experiment_timeout"))
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		// The client has gone away
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, CodeValidationFailed, tr(r, "error.invalid_experiment"),
+			FieldError{Field: "sweep", Message: trErr(r, err)})
+		return
+	}
+
+	id := s.experiments.add(sweep)
+	base := APIPrefix + "/experiments/" + id
+
+	downloads := map[string]string{
+		"json": base + "?format=json",
+		"csv":  base + "?format=csv",
+		"text": base + "?format=text",
+	}
+	for _, cm := range batch.ChartMetrics {
+		downloads[cm.Name+".svg"] = base + "/charts/" + cm.Name + ".svg"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", base)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ExperimentResponse{
+		ID:             id,
+		Configurations: len(sweep.Rows),
+		Elapsed:        sweep.Elapsed.Milliseconds(),
+		Rows:           sweep.Rows,
+		Downloads:      downloads,
+	})
+}
+
+// HandleExperiment serves the results of a stored experiment as json, csv or text
+func (s *Server) HandleExperiment(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	id := r.PathValue("id")
+	sweep, ok := s.experiments.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeNotFound, tr(r, "error.experiment_not_found"))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	contentTypes := map[string]string{
+		"json": "application/json",
+		"csv":  "text/csv; charset=utf-8",
+		"text": "text/plain; charset=utf-8",
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, tr(r, "error.experiment_format"),
+			FieldError{Field: "format", Message: tr(r, "field.experiment_format")})
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if format != "json" {
+		ext := map[string]string{"csv": "csv", "text": "txt"}[format]
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, id, ext))
+	}
+	sweep.Write(w, format)
+}
+
+// HandleExperimentChart serves an SVG chart of a stored experiment; the
+// chart path segment is the metric name followed by .svg
+func (s *Server) HandleExperimentChart(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	sweep, ok := s.experiments.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, CodeNotFound, tr(r, "error.experiment_not_found"))
+		return
+	}
+
+	metric, isSVG := strings.CutSuffix(r.PathValue("chart"), ".svg")
+	known := false
+	for _, cm := range batch.ChartMetrics {
+		known = known || cm.Name == metric
+	}
+	if !isSVG || !known {
+		writeError(w, http.StatusNotFound, CodeNotFound, tr(r, "error.chart_not_found"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	sweep.WriteSVG(w, metric)
+}
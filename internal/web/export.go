@@ -0,0 +1,127 @@
+package web
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"juggler/internal/juggler"
+)
+
+// ExportResponse is the JSON export of a session timeline
+type ExportResponse struct {
+	StartTime  time.Time             `json:"start_time"`
+	TotalBalls int                   `json:"total_balls"`
+	TotalTime  int                   `json:"total_time"`
+	IsRunning  bool                  `json:"is_running"`
+	Events     []ExportEvent         `json:"events"`
+	Balls      []juggler.BallSummary `json:"balls"`
+}
+
+// ExportEvent is one entry of an exported timeline
+type ExportEvent struct {
+	juggler.Event
+	Offset float64 `json:"offset"` // seconds since the session started
+}
+
+// HandleExport serves the timeline of the current or most recent session:
+// /api/export?format=json returns events and per-ball summaries together,
+// format=csv or format=jsonl returns one table selected by table=events|balls
+func (s *Server) HandleExport(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	table := r.URL.Query().Get("table")
+	if table == "" {
+		table = "events"
+	}
+	if table != "events" && table != "balls" {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, tr(r, "error.export_table"),
+			FieldError{Field: "table", Message: tr(r, "field.export_table")})
+		return
+	}
+
+	start := s.juggler.GetStartTime()
+	events := s.juggler.GetEvents()
+	exported := make([]ExportEvent, len(events))
+	for i, e := range events {
+		exported[i] = ExportEvent{Event: e, Offset: e.Time.Sub(start).Seconds()}
+	}
+	balls := juggler.SummarizeBalls(events)
+
+	filename := "juggler-session-" + start.Format("20060102-150405")
+	if format != "json" {
+		filename += "-" + table
+	}
+
+	switch format {
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, filename))
+		json.NewEncoder(w).Encode(ExportResponse{
+			StartTime:  start,
+			TotalBalls: s.juggler.GetTotalBalls(),
+			TotalTime:  int(s.juggler.GetJugglingTime().Minutes()),
+			IsRunning:  s.juggler.IsRunning(),
+			Events:     exported,
+			Balls:      balls,
+		})
+	case "jsonl":
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.jsonl"`, filename))
+		enc := json.NewEncoder(w)
+		if table == "balls" {
+			for _, b := range balls {
+				enc.Encode(b)
+			}
+		} else {
+			for _, e := range exported {
+				enc.Encode(e)
+			}
+		}
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		cw := csv.NewWriter(w)
+		if table == "balls" {
+			cw.Write([]string{"ball_id", "throws", "catches", "drops", "air_time"})
+			for _, b := range balls {
+				cw.Write([]string{
+					strconv.Itoa(b.BallID),
+					strconv.Itoa(b.Throws),
+					strconv.Itoa(b.Catches),
+					strconv.Itoa(b.Drops),
+					strconv.FormatFloat(b.AirTime.Seconds(), 'f', -1, 64),
+				})
+			}
+		} else {
+			cw.Write([]string{"timestamp", "offset", "ball_id", "event", "hand", "flight_time"})
+			for _, e := range exported {
+				flightTime := ""
+				if e.Type == "throw" {
+					flightTime = strconv.Itoa(e.FlightTime)
+				}
+				cw.Write([]string{
+					e.Time.Format(time.RFC3339Nano),
+					strconv.FormatFloat(e.Offset, 'f', 3, 64),
+					strconv.Itoa(e.BallID),
+					e.Type,
+					e.Hand,
+					flightTime,
+				})
+			}
+		}
+		cw.Flush()
+	default:
+		writeError(w, http.StatusBadRequest, CodeBadRequest, tr(r, "error.export_format"),
+			FieldError{Field: "format", Message: tr(r, "field.export_format")})
+	}
+}
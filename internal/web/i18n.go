@@ -0,0 +1,37 @@
+package web
+
+import (
+	"net/http"
+
+	"juggler/internal/i18n"
+)
+
+// LanguageCookie names the cookie holding the language chosen on the page
+const LanguageCookie = "juggler_lang"
+
+// language returns the language to answer a request in: the lang query
+// parameter, the page's language cookie or the Accept-Language header, in
+// that order. Clients stating no preference get English, as API clients
+// always have.
+func language(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); i18n.Supported(lang) {
+		return lang
+	}
+	if cookie, err := r.Cookie(LanguageCookie); err == nil && i18n.Supported(cookie.Value) {
+		return cookie.Value
+	}
+	if lang := i18n.Match(r.Header.Get("Accept-Language")); lang != "" {
+		return lang
+	}
+	return i18n.English
+}
+
+// tr returns the message with the key in the request's language
+func tr(r *http.Request, key string, args ...any) string {
+	return i18n.T(language(r), key, args...)
+}
+
+// trErr returns an error's message in the request's language
+func trErr(r *http.Request, err error) string {
+	return i18n.Message(language(r), err)
+}
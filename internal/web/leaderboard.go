@@ -0,0 +1,180 @@
+package web
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"juggler/internal/i18n"
+	"juggler/internal/juggler"
+	"juggler/internal/leaderboard"
+	"juggler/internal/score"
+)
+
+// defaultLeaderboardLimit is how many runs the leaderboard returns unless asked otherwise
+const defaultLeaderboardLimit = 10
+
+// PlayerRequest represents the request to create a player profile
+type PlayerRequest struct {
+	Name string `json:"name"`
+}
+
+// LeaderboardEntry is one ranked run
+type LeaderboardEntry struct {
+	Rank  int     `json:"rank"`
+	Value float64 `json:"value"` // the run's value of the ranking metric
+	leaderboard.Run
+}
+
+// LeaderboardResponse represents the ranked runs
+type LeaderboardResponse struct {
+	Metric  string             `json:"metric"`
+	Balls   int                `json:"balls,omitempty"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// SetLeaderboard replaces the in-memory leaderboard, typically with one
+// stored in a file
+func (s *Server) SetLeaderboard(st *leaderboard.Store) {
+	s.leaderboard = st
+}
+
+// HandlePlayers lists the player profiles
+func (s *Server) HandlePlayers(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.leaderboard.Players())
+}
+
+// HandleAddPlayer creates a player profile
+func (s *Server) HandleAddPlayer(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req PlayerRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	p, err := s.leaderboard.AddPlayer(req.Name)
+	switch {
+	case errors.Is(err, leaderboard.ErrInvalidName):
+		writeError(w, http.StatusUnprocessableEntity, CodeValidationFailed, tr(r, "error.invalid_player_name"),
+			FieldError{Field: "name", Message: trErr(r, err)})
+		return
+	case errors.Is(err, leaderboard.ErrPlayerExists):
+		writeError(w, http.StatusConflict, CodeConflict, tr(r, "error.player_exists"))
+		return
+	case err != nil:
+		// The profile was created but could not be saved
+		log.Print(i18n.L("log.leaderboard_save_failed", err))
+	}
+
+	writeJSON(w, http.StatusCreated, p)
+}
+
+// HandleLeaderboard ranks the best runs by ?metric= (points by default),
+// optionally only those with ?balls=, returning up to ?limit= entries
+func (s *Server) HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	metric := query.Get("metric")
+	if metric == "" {
+		metric = "points"
+	}
+
+	var details []FieldError
+	balls, err := optionalInt(query.Get("balls"), 0)
+	if err != nil || balls < 0 {
+		details = append(details, FieldError{Field: "balls", Message: tr(r, "field.non_negative_integer")})
+	}
+	limit, err := optionalInt(query.Get("limit"), defaultLeaderboardLimit)
+	if err != nil || limit <= 0 {
+		details = append(details, FieldError{Field: "limit", Message: tr(r, "field.positive_integer")})
+	}
+	if _, ok := leaderboard.Metrics[metric]; !ok {
+		names := make([]string, 0, len(leaderboard.Metrics))
+		for name := range leaderboard.Metrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		details = append(details, FieldError{Field: "metric", Message: tr(r, "field.one_of", strings.Join(names, ", "))})
+	}
+	if len(details) > 0 {
+		writeError(w, http.StatusBadRequest, CodeBadRequest, tr(r, "error.invalid_leaderboard_query"), details...)
+		return
+	}
+
+	runs, _ := s.leaderboard.Top(metric, balls, limit)
+	resp := LeaderboardResponse{Metric: metric, Balls: balls, Entries: make([]LeaderboardEntry, 0, len(runs))}
+	for i, run := range runs {
+		resp.Entries = append(resp.Entries, LeaderboardEntry{
+			Rank:  i + 1,
+			Value: leaderboard.Metrics[metric](run),
+			Run:   run,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// optionalInt parses a query value, returning def when it is empty
+func optionalInt(value string, def int) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// watchSession records the session's run once it ends and its balls have landed
+func (s *Server) watchSession(id int, done <-chan struct{}) {
+	<-done
+
+	s.sessions.mu.Lock()
+	defer s.sessions.mu.Unlock()
+	if s.juggler.GetSession() == id {
+		s.recordRun()
+	}
+}
+
+// recordRun adds the current session to the leaderboard if it was started
+// for a player and has not been recorded yet. The caller must hold s.sessions.mu.
+func (s *Server) recordRun() {
+	cur := s.sessions.current
+	if cur == nil || cur.recorded || cur.player == "" {
+		return
+	}
+	cur.recorded = true
+
+	events := s.juggler.GetEvents()
+	if len(events) == 0 {
+		return
+	}
+
+	sc := score.Compute(events)
+	summary := juggler.Summarize(events)
+	run := leaderboard.Run{
+		Player:           cur.player,
+		Session:          cur.id,
+		Balls:            summary.Balls,
+		Manual:           cur.manual,
+		StartTime:        s.juggler.GetStartTime(),
+		Seconds:          int(summary.Duration.Seconds()),
+		Points:           sc.Points,
+		LongestStreak:    sc.LongestStreak,
+		Catches:          sc.Catches,
+		Drops:            sc.Drops,
+		CatchesPerMinute: sc.CatchesPerMinute,
+	}
+	if err := s.leaderboard.Record(run); err != nil {
+		log.Print(i18n.L("log.leaderboard_save_failed", err))
+	}
+}
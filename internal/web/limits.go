@@ -0,0 +1,166 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limits protects the server from misbehaving clients
+type Limits struct {
+	// RequestsPerSecond is how many API requests each client may make per
+	// second on average, in bursts of up to Burst; 0 turns rate limiting off
+	RequestsPerSecond float64
+	Burst             int
+
+	MaxBodyBytes int64 // largest request body accepted, 0 for no limit
+
+	// Timeouts of the HTTP server, 0 for none
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration // long enough for POST /experiments to run its sweep
+	IdleTimeout  time.Duration
+}
+
+// DefaultLimits returns the limits a new server starts with, the same as
+// the defaults of juggler serve: 10 API requests per second in bursts of
+// 20, enough for the page's polling and a script or two
+func DefaultLimits() Limits {
+	return Limits{
+		RequestsPerSecond: 10,
+		Burst:             20,
+		MaxBodyBytes:      1 << 20,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      2 * time.Minute,
+		IdleTimeout:       2 * time.Minute,
+	}
+}
+
+// SetLimits replaces the limits; call it before Handler or Start
+func (s *Server) SetLimits(l Limits) {
+	s.limits = l
+}
+
+// LimitBody caps request bodies at limit bytes; reading past the cap
+// fails with *http.MaxBytesError, which readJSON answers with 413
+func LimitBody(limit int64, next http.Handler) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RateLimit gives every client, told apart by IP address, a token bucket
+// holding up to burst requests and refilled at perSecond. Requests finding
+// the bucket empty get 429 with Retry-After.
+func RateLimit(perSecond float64, burst int, next http.Handler) http.Handler {
+	if perSecond <= 0 {
+		return next
+	}
+	l := &rateLimiter{rate: perSecond, burst: float64(max(burst, 1)), buckets: make(map[string]*bucket)}
+	return l.wrap(next)
+}
+
+// bucket is the token bucket of one client
+type bucket struct {
+	tokens float64
+	at     time.Time // when tokens was last brought up to date
+}
+
+// rateLimiter holds the buckets of all clients
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// wrap returns next guarded by the limiter
+func (l *rateLimiter) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wait, ok := l.take(clientIP(r)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, CodeRateLimited, tr(r, "error.rate_limited"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// take spends a token of the client, or returns how long until one is available
+func (l *rateLimiter) take(client string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, at: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.at).Seconds()*l.rate)
+	b.at = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / l.rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// sweep forgets clients whose buckets have refilled, at most once a
+// minute. The caller must hold the lock.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	full := time.Duration(l.burst / l.rate * float64(time.Second))
+	for client, b := range l.buckets {
+		if now.Sub(b.at) >= full {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// clientIP returns the IP address a request came from
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// readJSON decodes the request body into v. It answers 413 if the body
+// is over the limit and 400 if it is not valid JSON, returning false.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeBodyError(w, r, err)
+		return false
+	}
+	return true
+}
+
+// writeBodyError answers a request whose body could not be decoded
+func writeBodyError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, CodeBodyTooLarge,
+			tr(r, "error.body_too_large", tooLarge.Limit))
+		return
+	}
+	writeError(w, http.StatusBadRequest, CodeInvalidBody, tr(r, "error.invalid_body"))
+}
@@ -0,0 +1,27 @@
+package web
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec is the OpenAPI 3 description of the HTTP API. Tests check
+// that it documents every route and matches the request and response types.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPISpec returns the OpenAPI 3 document describing the API
+func OpenAPISpec() []byte {
+	return openAPISpec
+}
+
+// HandleOpenAPI serves the OpenAPI specification
+func (s *Server) HandleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"net/http"
+	"time"
+)
+
+// EngineOptions represents the engine settings shared by every session;
+// the per-session settings are part of StartRequest
+type EngineOptions struct {
+	MinFlightTime   int     `json:"min_flight_time"` // seconds
+	MaxFlightTime   int     `json:"max_flight_time"` // seconds
+	ThrowIntervalMS int     `json:"throw_interval_ms"`
+	DropChance      float64 `json:"drop_chance"`
+	HandCapacity    int     `json:"hand_capacity"` // balls one hand can hold in manual mode, 0 means 2
+	HoldTimeMS      int     `json:"hold_time_ms"`  // how long a ball may be held in manual mode, 0 means 3 seconds
+}
+
+// HandleOptions serves the engine options
+func (s *Server) HandleOptions(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.engineOptions())
+}
+
+// engineOptions returns the engine options of the juggler
+func (s *Server) engineOptions() EngineOptions {
+	o := s.juggler.GetOptions()
+	return EngineOptions{
+		MinFlightTime:   o.MinFlightTime,
+		MaxFlightTime:   o.MaxFlightTime,
+		ThrowIntervalMS: int(o.ThrowInterval / time.Millisecond),
+		DropChance:      o.DropChance,
+		HandCapacity:    o.HandCapacity,
+		HoldTimeMS:      int(o.HoldTime / time.Millisecond),
+	}
+}
+
+// HandleSetOptions replaces the engine options. A running session uses
+// the new flight times and drop chance at once and the new throw interval
+// from its next start.
+func (s *Server) HandleSetOptions(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	var req EngineOptions
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	o := s.juggler.GetOptions()
+	o.MinFlightTime = req.MinFlightTime
+	o.MaxFlightTime = req.MaxFlightTime
+	o.ThrowInterval = time.Duration(req.ThrowIntervalMS) * time.Millisecond
+	o.DropChance = req.DropChance
+	o.HandCapacity = req.HandCapacity
+	o.HoldTime = time.Duration(req.HoldTimeMS) * time.Millisecond
+	if err := s.juggler.SetOptions(o); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, CodeValidationFailed, tr(r, "error.invalid_options"),
+			FieldError{Field: "options", Message: trErr(r, err)})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.engineOptions())
+}
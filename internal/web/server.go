@@ -1,13 +1,22 @@
 package web
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"reflect"
+	"strings"
 	"time"
 
+	"juggler/internal/auth"
+	"juggler/internal/i18n"
 	"juggler/internal/juggler"
+	"juggler/internal/leaderboard"
+	"juggler/internal/passing"
+	"juggler/internal/peer"
+	"juggler/internal/score"
 )
 
 // StatsResponse represents the JSON response for stats
@@ -20,335 +29,226 @@ type StatsResponse struct {
 	IsRunning   bool           `json:"is_running"`
 	TotalBalls  int            `json:"total_balls"`
 	TotalTime   int            `json:"total_time"`
+	Manual      bool           `json:"manual"`
+	Score       score.Score    `json:"score"`
+
+	// Performer holds the live gauges of the performer model, if it is on
+	Performer *juggler.PerformerState `json:"performer,omitempty"`
+
+	// Passing and Troupe describe a troupe session
+	Passing string                 `json:"passing,omitempty"`
+	Troupe  []juggler.TroupeMember `json:"troupe,omitempty"`
+
+	// Peer describes the link to a juggler in another process, if configured
+	Peer *peer.Status `json:"peer,omitempty"`
 }
 
 // StartRequest represents the request to start juggling
 type StartRequest struct {
-	TotalBalls  int `json:"total_balls"`
-	TimeMinutes int `json:"time_minutes"`
+	TotalBalls  int    `json:"total_balls"`
+	TimeMinutes int    `json:"time_minutes"`
+	Mode        string `json:"mode,omitempty"` // what to do if a session is running, see StartModeReject
+	Manual      bool   `json:"manual,omitempty"`
+	Player      string `json:"player,omitempty"` // profile the session's run is recorded for
+
+	// Balls defines the props in order, cycling when there are fewer
+	// definitions than balls; none means standard balls
+	Balls []juggler.BallDef `json:"balls,omitempty"`
+
+	// Performer turns on the skill and stamina model when its skill is set
+	Performer juggler.PerformerOptions `json:"performer,omitzero"`
+
+	// Passing makes the session a troupe passing in this pattern, e.g. "p s | p s"
+	Passing string `json:"passing,omitempty"`
+}
+
+// validate returns the invalid fields of the request, with messages in lang
+func (req StartRequest) validate(lang string) []FieldError {
+	var details []FieldError
+	if req.TotalBalls <= 0 {
+		details = append(details, FieldError{Field: "total_balls", Message: i18n.T(lang, "field.positive")})
+	}
+	if req.TimeMinutes <= 0 {
+		details = append(details, FieldError{Field: "time_minutes", Message: i18n.T(lang, "field.positive")})
+	}
+	switch req.Mode {
+	case "", StartModeReject, StartModeRestart, StartModeQueue:
+	default:
+		details = append(details, FieldError{Field: "mode", Message: i18n.T(lang, "field.start_mode")})
+	}
+	if err := req.Performer.Validate(); err != nil {
+		details = append(details, FieldError{Field: "performer", Message: i18n.Message(lang, err)})
+	}
+	if req.Passing != "" {
+		if _, err := passing.Parse(req.Passing); err != nil {
+			details = append(details, FieldError{Field: "passing", Message: i18n.Message(lang, err)})
+		}
+	}
+	for i, def := range req.Balls {
+		if err := def.Validate(); err != nil {
+			details = append(details, FieldError{Field: fmt.Sprintf("balls[%d]", i), Message: i18n.Message(lang, err)})
+		}
+	}
+	return details
 }
 
 // Server represents the web server
 type Server struct {
-	juggler *juggler.Juggler
-	port    int
+	juggler     *juggler.Juggler
+	port        int
+	experiments experimentStore
+	sessions    sessionControl
+	leaderboard *leaderboard.Store
+	peer        *peer.Node
+	auth        *auth.Authenticator
+	limits      Limits
+	tls         *tls.Config
+	hstsMaxAge  time.Duration
+	cors        CORSOptions
+	assets      *assetStore
 }
 
 // NewServer creates a new web server
 func NewServer(j *juggler.Juggler, port int) *Server {
 	return &Server{
-		juggler: j,
-		port:    port,
+		juggler:     j,
+		port:        port,
+		leaderboard: leaderboard.NewStore(),
+		limits:      DefaultLimits(),
+		assets:      defaultAssets(),
 	}
 }
 
-// Start starts the web server
+// SetPeer shows the link to a juggler in another process in the stats
+func (s *Server) SetPeer(n *peer.Node) {
+	s.peer = n
+}
+
+// StatusResponse represents the JSON response of control endpoints
+type StatusResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// APIPrefix is the prefix of the current API version
+const APIPrefix = "/api/v1"
+
+// legacyAPIPrefix is the unversioned prefix kept as an alias of APIPrefix
+const legacyAPIPrefix = "/api"
+
+// Route binds a method and a ServeMux path pattern to the handler serving
+// it and the role a caller needs when authentication is on; an empty role
+// is open to everyone
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.HandlerFunc
+	Role    auth.Role
+}
+
+// Routes returns the API routes, all under APIPrefix
+func (s *Server) Routes() []Route {
+	return []Route{
+		{http.MethodGet, APIPrefix + "/stats", s.HandleStats, auth.Viewer},
+		{http.MethodPost, APIPrefix + "/start", s.HandleStart, auth.Operator},
+		{http.MethodPost, APIPrefix + "/stop", s.HandleStop, auth.Operator},
+		{http.MethodPost, APIPrefix + "/balls", s.HandleAddBall, auth.Operator},
+		{http.MethodDelete, APIPrefix + "/balls/{id}", s.HandleRemoveBall, auth.Operator},
+		{http.MethodPost, APIPrefix + "/throw", s.HandleThrow, auth.Operator},
+		{http.MethodGet, APIPrefix + "/options", s.HandleOptions, auth.Viewer},
+		{http.MethodPut, APIPrefix + "/options", s.HandleSetOptions, auth.Admin},
+		{http.MethodGet, APIPrefix + "/players", s.HandlePlayers, auth.Viewer},
+		{http.MethodPost, APIPrefix + "/players", s.HandleAddPlayer, auth.Operator},
+		{http.MethodGet, APIPrefix + "/leaderboard", s.HandleLeaderboard, auth.Viewer},
+		{http.MethodGet, APIPrefix + "/export", s.HandleExport, auth.Viewer},
+		{http.MethodGet, APIPrefix + "/events", s.HandleEvents, auth.Viewer},
+		{http.MethodPost, APIPrefix + "/experiments", s.HandleExperiments, auth.Operator},
+		{http.MethodGet, APIPrefix + "/experiments/{id}", s.HandleExperiment, auth.Viewer},
+		{http.MethodGet, APIPrefix + "/experiments/{id}/charts/{chart}", s.HandleExperimentChart, auth.Viewer},
+		{http.MethodPost, APIPrefix + "/auth/login", s.HandleLogin, ""},
+		{http.MethodPost, APIPrefix + "/auth/logout", s.HandleLogout, ""},
+		{http.MethodGet, APIPrefix + "/auth/status", s.HandleAuthStatus, ""},
+		{http.MethodGet, APIPrefix + "/openapi.json", s.HandleOpenAPI, ""},
+	}
+}
+
+// Handler returns an HTTP handler serving the page and the API. Every API
+// route is also served under the legacy /api prefix, and requests with
+// other methods get a 405 error envelope. API requests are rate limited,
+// and all request bodies capped, as set with SetLimits; the page and its
+// static files are not limited, so that loading them leaves the whole
+// budget to the API.
+func (s *Server) Handler() http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("/", notFound)
+
+	allowed := make(map[string][]string)
+	var paths []string
+	for _, route := range s.Routes() {
+		legacy := legacyAPIPrefix + strings.TrimPrefix(route.Path, APIPrefix)
+		for _, path := range []string{route.Path, legacy} {
+			api.HandleFunc(route.Method+" "+path, s.requireRole(route.Role, route.Handler))
+			if _, ok := allowed[path]; !ok {
+				paths = append(paths, path)
+			}
+			allowed[path] = append(allowed[path], route.Method)
+		}
+	}
+
+	for _, path := range paths {
+		api.HandleFunc(path, methodNotAllowed(allowed[path]))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.HandleHome)
+	mux.HandleFunc("GET /embed", s.HandleEmbed)
+	mux.HandleFunc("GET /static/{name...}", s.HandleStatic)
+	mux.HandleFunc("/", notFound)
+	mux.Handle(legacyAPIPrefix+"/", RateLimit(s.limits.RequestsPerSecond, s.limits.Burst, api))
+
+	return HSTS(s.hstsMaxAge, CORS(s.cors, LimitBody(s.limits.MaxBodyBytes, mux)))
+}
+
+// Start starts the web server, serving HTTPS if SetTLS was called
 func (s *Server) Start() {
-	http.HandleFunc("/", s.HandleHome)
-	http.HandleFunc("/api/stats", s.HandleStats)
-	http.HandleFunc("/api/start", s.HandleStart)
-	http.HandleFunc("/api/stop", s.HandleStop)
-
-	addr := fmt.Sprintf(":%d", s.port)
-	log.Printf("Веб-сервер запущен на порту %d", s.port)
-	log.Fatal(http.ListenAndServe(addr, nil))
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.port),
+		Handler:      s.Handler(),
+		ReadTimeout:  s.limits.ReadTimeout,
+		WriteTimeout: s.limits.WriteTimeout,
+		IdleTimeout:  s.limits.IdleTimeout,
+		TLSConfig:    s.tls,
+	}
+	if s.tls != nil {
+		log.Print(i18n.L("log.web_started_https", s.port))
+		log.Fatal(server.ListenAndServeTLS("", ""))
+	}
+	log.Print(i18n.L("log.web_started", s.port))
+	log.Fatal(server.ListenAndServe())
 }
 
 // HandleHome serves the main HTML page
 func (s *Server) HandleHome(w http.ResponseWriter, r *http.Request) {
-	html := `
-<!DOCTYPE html>
-<html>
-<head>
-    <title>🤹 Жонглер - Интерактивный контроль</title>
-    <meta charset="utf-8">
-    <style>
-        body { font-family: Arial, sans-serif; margin: 20px; background-color: #f0f0f0; }
-        .container { max-width: 900px; margin: 0 auto; background: white; padding: 20px; border-radius: 10px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
-        h1 { color: #333; text-align: center; margin-bottom: 30px; }
-        
-        .controls { background: #f8f9fa; padding: 20px; border-radius: 8px; margin: 20px 0; border: 2px solid #e9ecef; }
-        .control-group { margin: 15px 0; display: flex; align-items: center; }
-        .control-group label { display: inline-block; width: 180px; font-weight: bold; color: #495057; }
-        .control-group input { padding: 10px; border: 2px solid #ced4da; border-radius: 5px; width: 120px; font-size: 16px; }
-        .control-group input:focus { border-color: #007bff; outline: none; }
-        
-        .control-buttons { margin-top: 25px; text-align: center; }
-        .btn { padding: 12px 30px; margin: 0 10px; border: none; border-radius: 6px; cursor: pointer; font-size: 16px; font-weight: bold; transition: all 0.3s; }
-        .btn-start { background-color: #28a745; color: white; }
-        .btn-stop { background-color: #dc3545; color: white; }
-        .btn:hover { transform: translateY(-2px); box-shadow: 0 4px 8px rgba(0,0,0,0.2); }
-        .btn:disabled { opacity: 0.5; cursor: not-allowed; transform: none; box-shadow: none; }
-        
-        .stats { display: grid; grid-template-columns: repeat(auto-fit, minmax(200px, 1fr)); gap: 20px; margin: 20px 0; }
-        .stat-card { background: #f8f9fa; padding: 20px; border-radius: 8px; text-align: center; border: 2px solid #e9ecef; }
-        .stat-number { font-size: 2.5em; font-weight: bold; color: #007bff; margin-bottom: 5px; }
-        .stat-label { font-size: 14px; color: #6c757d; font-weight: bold; }
-        
-        .status { text-align: center; margin: 20px 0; padding: 15px; border-radius: 8px; }
-        .status.running { background-color: #d4edda; border: 2px solid #c3e6cb; color: #155724; }
-        .status.stopped { background-color: #f8d7da; border: 2px solid #f5c6cb; color: #721c24; }
-        .status.finished { background-color: #d1ecf1; border: 2px solid #bee5eb; color: #0c5460; }
-        
-        .balls-container { margin: 25px 0; }
-        .balls-container h3 { color: #495057; margin-bottom: 15px; }
-        .ball { 
-            display: inline-block; 
-            margin: 8px; 
-            padding: 12px 18px; 
-            border-radius: 25px; 
-            color: white; 
-            font-weight: bold;
-            min-width: 100px;
-            text-align: center;
-            box-shadow: 0 2px 4px rgba(0,0,0,0.1);
-        }
-        .ball-in-hand { background: linear-gradient(135deg, #28a745, #20c997); }
-        .ball-in-flight { background: linear-gradient(135deg, #ffc107, #fd7e14); color: #000; }
-        .ball-dropped { background: linear-gradient(135deg, #dc3545, #e83e8c); }
-        
-        .time { font-size: 1.4em; color: #495057; text-align: center; margin: 20px 0; padding: 15px; background: #e9ecef; border-radius: 8px; }
-        .progress-bar { width: 100%; height: 10px; background: #e9ecef; border-radius: 5px; margin: 10px 0; overflow: hidden; }
-        .progress-fill { height: 100%; background: linear-gradient(90deg, #28a745, #20c997); transition: width 0.3s; }
-        
-        .message { text-align: center; margin: 15px 0; padding: 10px; border-radius: 5px; }
-        .message.success { background-color: #d4edda; color: #155724; border: 1px solid #c3e6cb; }
-        .message.error { background-color: #f8d7da; color: #721c24; border: 1px solid #f5c6cb; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <h1>🤹 Жонглер - Интерактивный контроль</h1>
-        
-        <div class="controls">
-            <h3>⚙️ Настройки жонглирования</h3>
-            <div class="control-group">
-                <label for="balls-input">Количество мячей:</label>
-                <input type="number" id="balls-input" min="1" max="10" value="3">
-            </div>
-            <div class="control-group">
-                <label for="time-input">Время (минуты):</label>
-                <input type="number" id="time-input" min="1" max="60" value="2">
-            </div>
-            <div class="control-buttons">
-                <button class="btn btn-start" id="start-btn" onclick="startJuggling()">🚀 Начать жонглирование</button>
-                <button class="btn btn-stop" id="stop-btn" onclick="stopJuggling()" disabled>🛑 Остановить</button>
-            </div>
-        </div>
-        
-        <div id="message" class="message" style="display: none;"></div>
-        
-        <div class="time" id="time">Время: 0 секунд</div>
-        <div class="progress-bar">
-            <div class="progress-fill" id="progress" style="width: 0%;"></div>
-        </div>
-        
-        <div class="stats">
-            <div class="stat-card">
-                <div class="stat-number" id="in-hand">0</div>
-                <div class="stat-label">В руках</div>
-            </div>
-            <div class="stat-card">
-                <div class="stat-number" id="in-air">0</div>
-                <div class="stat-label">В воздухе</div>
-            </div>
-            <div class="stat-card">
-                <div class="stat-number" id="total-balls">0</div>
-                <div class="stat-label">Всего мячей</div>
-            </div>
-            <div class="stat-card">
-                <div class="stat-number" id="total-time">0</div>
-                <div class="stat-label">Время (мин)</div>
-            </div>
-        </div>
-        
-        <div class="status stopped" id="status">
-            <span>⏹️ Жонглирование остановлено</span>
-        </div>
-        
-        <div class="balls-container">
-            <h3>🏀 Состояние мячей:</h3>
-            <div id="balls"></div>
-        </div>
-    </div>
-
-    <script>
-        let isRunning = false;
-        
-        function showMessage(text, type = 'success') {
-            const messageEl = document.getElementById('message');
-            messageEl.textContent = text;
-            messageEl.className = 'message ' + type;
-            messageEl.style.display = 'block';
-            setTimeout(() => {
-                messageEl.style.display = 'none';
-            }, 3000);
-        }
-        
-        function startJuggling() {
-            const balls = parseInt(document.getElementById('balls-input').value);
-            const time = parseInt(document.getElementById('time-input').value);
-            
-            if (balls < 1 || time < 1) {
-                showMessage('Количество мячей и время должны быть больше 0', 'error');
-                return;
-            }
-            
-            fetch('/api/start', {
-                method: 'POST',
-                headers: {
-                    'Content-Type': 'application/json'
-                },
-                body: JSON.stringify({
-                    total_balls: balls,
-                    time_minutes: time
-                })
-            })
-            .then(response => response.json())
-            .then(data => {
-                if (data.status === 'started') {
-                    isRunning = true;
-                    document.getElementById('start-btn').disabled = true;
-                    document.getElementById('stop-btn').disabled = false;
-                    document.getElementById('balls-input').disabled = true;
-                    document.getElementById('time-input').disabled = true;
-                    showMessage(data.message, 'success');
-                }
-            })
-            .catch(error => {
-                showMessage('Ошибка при запуске: ' + error, 'error');
-            });
-        }
-        
-        function stopJuggling() {
-            fetch('/api/stop', {
-                method: 'POST'
-            })
-            .then(response => response.json())
-            .then(data => {
-                if (data.status === 'stopped') {
-                    isRunning = false;
-                    document.getElementById('start-btn').disabled = false;
-                    document.getElementById('stop-btn').disabled = true;
-                    document.getElementById('balls-input').disabled = false;
-                    document.getElementById('time-input').disabled = false;
-                    showMessage(data.message, 'success');
-                }
-            })
-            .catch(error => {
-                showMessage('Ошибка при остановке: ' + error, 'error');
-            });
-        }
-        
-        function updateStats() {
-            fetch('/api/stats')
-                .then(response => response.json())
-                .then(data => {
-                    document.getElementById('in-hand').textContent = data.in_hand;
-                    document.getElementById('in-air').textContent = data.in_air;
-                    document.getElementById('total-balls').textContent = data.total_balls;
-                    document.getElementById('total-time').textContent = data.total_time;
-                    document.getElementById('time').textContent = 'Время: ' + data.time_elapsed + ' секунд';
-                    
-                    // Update progress bar
-                    const progress = data.total_time > 0 ? (data.time_elapsed / (data.total_time * 60)) * 100 : 0;
-                    document.getElementById('progress').style.width = Math.min(progress, 100) + '%';
-                    
-                    // Update status
-                    const statusElement = document.getElementById('status');
-                    if (data.is_running) {
-                        statusElement.className = 'status running';
-                        statusElement.innerHTML = '<span>🎯 Жонглирование активно</span>';
-                        isRunning = true;
-                        document.getElementById('start-btn').disabled = true;
-                        document.getElementById('stop-btn').disabled = false;
-                        document.getElementById('balls-input').disabled = true;
-                        document.getElementById('time-input').disabled = true;
-                    } else if (data.is_finished) {
-                        statusElement.className = 'status finished';
-                        statusElement.innerHTML = '<span>✅ Жонглирование завершено</span>';
-                        isRunning = false;
-                        document.getElementById('start-btn').disabled = false;
-                        document.getElementById('stop-btn').disabled = true;
-                        document.getElementById('balls-input').disabled = false;
-                        document.getElementById('time-input').disabled = false;
-                    } else {
-                        statusElement.className = 'status stopped';
-                        statusElement.innerHTML = '<span>⏹️ Жонглирование остановлено</span>';
-                        isRunning = false;
-                        document.getElementById('start-btn').disabled = false;
-                        document.getElementById('stop-btn').disabled = true;
-                        document.getElementById('balls-input').disabled = false;
-                        document.getElementById('time-input').disabled = false;
-                    }
-                    
-                    // Update balls - maintain consistent layout
-                    const ballsContainer = document.getElementById('balls');
-                    
-                    // Create a map of balls by ID for quick lookup
-                    const ballsById = {};
-                    data.balls.forEach(ball => {
-                        ballsById[ball.id] = ball;
-                    });
-                    
-                    // If total balls changed, recreate the container
-                    if (ballsContainer.children.length !== data.total_balls) {
-                        ballsContainer.innerHTML = '';
-                        for (let i = 1; i <= data.total_balls; i++) {
-                            const ballElement = document.createElement('div');
-                            ballElement.className = 'ball';
-                            ballElement.id = 'ball-' + i;
-                            ballsContainer.appendChild(ballElement);
-                        }
-                    }
-                    
-                    // Update each ball element in place
-                    for (let i = 1; i <= data.total_balls; i++) {
-                        const ballElement = document.getElementById('ball-' + i);
-                        const ball = ballsById[i];
-                        
-                        if (ball) {
-                            if (ball.status === 'in_hand') {
-                                ballElement.className = 'ball ball-in-hand';
-                                ballElement.textContent = '🏀 Мяч ' + ball.id;
-                            } else if (ball.status === 'in_flight') {
-                                ballElement.className = 'ball ball-in-flight';
-                                ballElement.textContent = '🚀 Мяч ' + ball.id + ' (' + ball.elapsed + '/' + ball.flight_time + 's)';
-                            } else {
-                                ballElement.className = 'ball ball-dropped';
-                                ballElement.textContent = '💥 Мяч ' + ball.id;
-                            }
-                        } else {
-                            // Ball doesn't exist, show as empty
-                            ballElement.className = 'ball';
-                            ballElement.textContent = '';
-                            ballElement.style.display = 'none';
-                        }
-                    }
-                })
-                .catch(error => {
-                    console.error('Ошибка при получении статистики:', error);
-                });
-        }
-        
-        // Update stats every second
-        setInterval(updateStats, 1000);
-        // Initial update but don't start juggling automatically
-        updateStats();
-    </script>
-</body>
-</html>`
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write([]byte(html))
+	s.renderPage(w, r, "home.html", homeView{Theme: theme(r), Themes: pageThemes})
 }
 
 // HandleStats serves the stats API endpoint
 func (s *Server) HandleStats(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.Stats())
+}
+
+// Stats returns a snapshot of the juggler as served by GET /stats
+func (s *Server) Stats() StatsResponse {
 	inHand, inAir, balls := s.juggler.GetStats()
 
 	var timeElapsed int
 	if s.juggler.IsRunning() {
-		timeElapsed = int(time.Since(s.juggler.GetStartTime()).Seconds())
+		timeElapsed = int(s.juggler.GetElapsed().Seconds())
 	} else {
 		timeElapsed = 0
 	}
@@ -362,53 +262,83 @@ func (s *Server) HandleStats(w http.ResponseWriter, r *http.Request) {
 		IsRunning:   s.juggler.IsRunning(),
 		TotalBalls:  s.juggler.GetTotalBalls(),
 		TotalTime:   int(s.juggler.GetJugglingTime().Minutes()),
+		Manual:      s.juggler.GetOptions().Manual,
+		Score:       score.Compute(s.juggler.GetEvents()),
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(stats)
+	if performer, ok := s.juggler.GetPerformer(); ok {
+		stats.Performer = &performer
+	}
+	if s.peer != nil {
+		status := s.peer.Status()
+		stats.Peer = &status
+	}
+	if troupe := s.juggler.GetTroupe(); troupe != nil {
+		stats.Passing = s.juggler.GetOptions().Passing
+		stats.Troupe = troupe
+	}
+	return stats
 }
 
 // HandleStart handles requests to start juggling
 func (s *Server) HandleStart(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	if !requireMethod(w, r, http.MethodPost) {
 		return
 	}
 
 	var req StartRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !readJSON(w, r, &req) {
 		return
 	}
 
-	if req.TotalBalls <= 0 || req.TimeMinutes <= 0 {
-		http.Error(w, "Balls and time must be positive", http.StatusBadRequest)
+	if err := s.checkStart(&req, language(r)); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, CodeValidationFailed, err.Message, err.Details...)
 		return
 	}
 
-	s.juggler.Reset(req.TotalBalls, req.TimeMinutes)
+	s.sessions.mu.Lock()
+	defer s.sessions.mu.Unlock()
 
-	s.juggler.Start()
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if key != "" {
+		if saved, ok := s.sessions.replies.get(key); ok {
+			if !reflect.DeepEqual(saved.request, req) {
+				writeError(w, http.StatusUnprocessableEntity, CodeIdempotencyMismatch,
+					tr(r, "error.idempotency_mismatch"))
+				return
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			writeJSON(w, saved.status, saved.response)
+			return
+		}
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"status":  "started",
-		"message": fmt.Sprintf("Juggling started with %d balls for %d minutes", req.TotalBalls, req.TimeMinutes),
-	})
+	status, resp, invalid := s.startSession(req, language(r))
+	if invalid != nil {
+		writeError(w, http.StatusUnprocessableEntity, CodeValidationFailed, invalid.Message, invalid.Details...)
+		return
+	}
+	if status == http.StatusConflict {
+		writeError(w, http.StatusConflict, CodeConflict, tr(r, "error.already_running"))
+		return
+	}
+
+	if key != "" {
+		s.sessions.replies.add(key, savedReply{request: req, status: status, response: resp})
+	}
+	writeJSON(w, status, resp)
 }
 
 // HandleStop handles requests to stop juggling
 func (s *Server) HandleStop(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	if !requireMethod(w, r, http.MethodPost) {
 		return
 	}
 
-	s.juggler.Stop()
+	s.StopSession()
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
-		"status":  "stopped",
-		"message": "Juggling stopped",
+	json.NewEncoder(w).Encode(StatusResponse{
+		Status:  "stopped",
+		Message: tr(r, "status.stopped"),
 	})
 }
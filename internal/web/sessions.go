@@ -0,0 +1,294 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"juggler/internal/i18n"
+)
+
+// Start modes deciding what POST /start does while a session is running
+const (
+	StartModeReject  = "reject"  // answer 409 and leave the running and queued sessions alone (default)
+	StartModeRestart = "restart" // end the running session, drop queued ones and start the new one at once
+	StartModeQueue   = "queue"   // start the new session when the running and queued ones have finished
+)
+
+// IdempotencyKeyHeader names the request header that makes a start
+// request safe to retry: a repeated key replays the first response
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeys is how many idempotency keys are remembered
+const maxIdempotencyKeys = 100
+
+// SessionInfo identifies a juggling session
+type SessionInfo struct {
+	ID          int       `json:"id"`
+	Player      string    `json:"player,omitempty"`
+	TotalBalls  int       `json:"total_balls"`
+	TimeMinutes int       `json:"time_minutes"`
+	StartTime   time.Time `json:"start_time"`
+}
+
+// StartResponse represents the response to a start request
+type StartResponse struct {
+	Status        string       `json:"status"` // "started", "restarted" or "queued"
+	Message       string       `json:"message"`
+	Session       *SessionInfo `json:"session,omitempty"`        // the started session, unset when queued
+	QueuePosition int          `json:"queue_position,omitempty"` // 1 for the next session to start
+}
+
+// sessionControl serializes start and stop requests and holds queued starts
+type sessionControl struct {
+	mu       sync.Mutex
+	queue    []StartRequest
+	watching bool
+	replies  replyStore
+	current  *currentSession
+}
+
+// currentSession remembers who started the running session so that its
+// run can be recorded on the leaderboard once it ends
+type currentSession struct {
+	id       int
+	player   string
+	manual   bool
+	recorded bool
+}
+
+// savedReply is the outcome of a start request remembered under its idempotency key
+type savedReply struct {
+	request  StartRequest
+	status   int
+	response StartResponse
+}
+
+// replyStore keeps the most recent start responses by idempotency key
+type replyStore struct {
+	order   []string
+	replies map[string]savedReply
+}
+
+// add stores a reply, evicting the oldest one when the store is full
+func (st *replyStore) add(key string, reply savedReply) {
+	if st.replies == nil {
+		st.replies = make(map[string]savedReply)
+	}
+
+	st.replies[key] = reply
+	st.order = append(st.order, key)
+
+	if len(st.order) > maxIdempotencyKeys {
+		delete(st.replies, st.order[0])
+		st.order = st.order[1:]
+	}
+}
+
+// get returns the reply stored under key
+func (st *replyStore) get(key string) (savedReply, bool) {
+	reply, ok := st.replies[key]
+	return reply, ok
+}
+
+// ErrConflict is returned by StartSession when a session is running and
+// the request's mode rejects it
+var ErrConflict = errors.New("juggling is already running")
+
+// ValidationError describes why a start request is invalid
+type ValidationError struct {
+	Message string
+	Details []FieldError
+}
+
+// newValidationError returns a validation error with the message of the key in lang
+func newValidationError(lang, key string, details []FieldError) *ValidationError {
+	return &ValidationError{Message: i18n.T(lang, key), Details: details}
+}
+
+func (e *ValidationError) Error() string {
+	msg := e.Message
+	for _, d := range e.Details {
+		msg += fmt.Sprintf("; %s %s", d.Field, d.Message)
+	}
+	return msg
+}
+
+// checkStart validates a start request and resolves its player's name,
+// describing problems in lang
+func (s *Server) checkStart(req *StartRequest, lang string) *ValidationError {
+	if details := req.validate(lang); len(details) > 0 {
+		return newValidationError(lang, "error.invalid_start", details)
+	}
+
+	if req.Player != "" {
+		p, ok := s.leaderboard.Player(req.Player)
+		if !ok {
+			return newValidationError(lang, "error.unknown_player", []FieldError{
+				{Field: "player", Message: i18n.T(lang, "field.unknown_player")},
+			})
+		}
+		req.Player = p.Name
+	}
+	return nil
+}
+
+// StartSession starts a session as POST /start does, without
+// idempotency keys, with messages in lang. It returns a *ValidationError
+// for an invalid request and ErrConflict if the request is rejected.
+func (s *Server) StartSession(req StartRequest, lang string) (StartResponse, error) {
+	if err := s.checkStart(&req, lang); err != nil {
+		return StartResponse{}, err
+	}
+
+	s.sessions.mu.Lock()
+	defer s.sessions.mu.Unlock()
+
+	status, resp, invalid := s.startSession(req, lang)
+	if invalid != nil {
+		return StartResponse{}, invalid
+	}
+	if status == http.StatusConflict {
+		return StartResponse{}, ErrConflict
+	}
+	return resp, nil
+}
+
+// StopSession stops the running session and drops queued ones, as POST /stop does
+func (s *Server) StopSession() {
+	s.sessions.mu.Lock()
+	defer s.sessions.mu.Unlock()
+
+	s.sessions.queue = nil
+	s.juggler.Stop()
+}
+
+// startSession applies the request's mode and returns the status code and
+// response, with its message in lang, or 409 if the request is rejected.
+// A request the engine refuses to start gets a validation error. While
+// sessions are queued, even between two of them, a start that does not
+// queue is rejected, or drops the queue when it restarts, so that the queue
+// never starts over it. The caller must hold s.sessions.mu.
+func (s *Server) startSession(req StartRequest, lang string) (int, StartResponse, *ValidationError) {
+	running := s.juggler.IsRunning()
+	queued := len(s.sessions.queue) > 0
+
+	switch {
+	case req.Mode == StartModeQueue && (running || queued):
+		s.sessions.queue = append(s.sessions.queue, req)
+		if !s.sessions.watching {
+			s.sessions.watching = true
+			go s.runQueue()
+		}
+		return http.StatusAccepted, StartResponse{
+			Status:        "queued",
+			Message:       i18n.T(lang, "status.queued", req.TotalBalls, req.TimeMinutes),
+			QueuePosition: len(s.sessions.queue),
+		}, nil
+	case running && req.Mode == StartModeRestart:
+		// Reset begins a new session; the old one's goroutines exit on their next tick
+		session, err := s.begin(req)
+		if err != nil {
+			return http.StatusUnprocessableEntity, StartResponse{}, optionsError(lang, err)
+		}
+		s.sessions.queue = nil
+		return http.StatusOK, StartResponse{
+			Status:  "restarted",
+			Message: i18n.T(lang, "status.restarted", req.TotalBalls, req.TimeMinutes),
+			Session: session,
+		}, nil
+	case running || (queued && req.Mode != StartModeRestart):
+		return http.StatusConflict, StartResponse{}, nil
+	}
+
+	session, err := s.begin(req)
+	if err != nil {
+		return http.StatusUnprocessableEntity, StartResponse{}, optionsError(lang, err)
+	}
+	s.sessions.queue = nil
+	return http.StatusOK, StartResponse{
+		Status:  "started",
+		Message: i18n.T(lang, "status.started", req.TotalBalls, req.TimeMinutes),
+		Session: session,
+	}, nil
+}
+
+// optionsError describes engine options refused by the juggler, as
+// PUT /options does
+func optionsError(lang string, err error) *ValidationError {
+	return newValidationError(lang, "error.invalid_options", []FieldError{
+		{Field: "options", Message: i18n.Message(lang, err)},
+	})
+}
+
+// begin resets the juggler for a new session and starts it. It returns
+// the juggler's error, leaving the running session alone, if the
+// request's options do not go with the engine's.
+func (s *Server) begin(req StartRequest) (*SessionInfo, error) {
+	opts := s.juggler.GetOptions()
+	opts.Manual = req.Manual
+	opts.Performer = req.Performer
+	opts.Passing = req.Passing
+	if err := s.juggler.SetOptions(opts); err != nil {
+		return nil, err
+	}
+	s.juggler.SetBallDefs(req.Balls) // validated with the request
+
+	// The previous session's events are lost on Reset, so record it now
+	s.recordRun()
+
+	s.juggler.Reset(req.TotalBalls, req.TimeMinutes)
+	s.juggler.Start()
+
+	id := s.juggler.GetSession()
+	s.sessions.current = &currentSession{id: id, player: req.Player, manual: req.Manual}
+	if req.Player != "" {
+		go s.watchSession(id, s.juggler.Done())
+	}
+
+	return &SessionInfo{
+		ID:          id,
+		Player:      req.Player,
+		TotalBalls:  req.TotalBalls,
+		TimeMinutes: req.TimeMinutes,
+		StartTime:   s.juggler.GetStartTime(),
+	}, nil
+}
+
+// runQueue starts queued sessions one after another, each once the
+// previous session has finished and its balls have landed
+func (s *Server) runQueue() {
+	for {
+		<-s.juggler.Done()
+
+		s.sessions.mu.Lock()
+		if s.juggler.IsRunning() {
+			// Restarted while waiting; wait for the new session instead
+			s.sessions.mu.Unlock()
+			continue
+		}
+		if len(s.sessions.queue) == 0 {
+			s.sessions.watching = false
+			s.sessions.mu.Unlock()
+			return
+		}
+
+		req := s.sessions.queue[0]
+		s.sessions.queue = s.sessions.queue[1:]
+		if _, err := s.begin(req); err != nil {
+			log.Print(i18n.L("log.queue_start_failed", req.TotalBalls, req.TimeMinutes, i18n.Message(i18n.Local(), err)))
+		}
+		s.sessions.mu.Unlock()
+	}
+}
+
+// writeJSON replies with v encoded as JSON and the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
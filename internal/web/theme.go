@@ -0,0 +1,34 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+)
+
+// ThemeCookie names the cookie holding the color theme chosen on the page
+const ThemeCookie = "juggler_theme"
+
+// pageThemes are the themes a page may be forced into; without one it
+// follows the browser's prefers-color-scheme
+var pageThemes = []string{"light", "dark"}
+
+// homeView is what the home template renders
+type homeView struct {
+	Theme  string // "light", "dark" or empty to follow the system
+	Themes []string
+}
+
+// theme returns the color theme chosen by the theme query parameter or the
+// page's theme cookie, or "" to follow the system
+func theme(r *http.Request) string {
+	chosen := r.URL.Query().Get("theme")
+	if chosen == "" {
+		if cookie, err := r.Cookie(ThemeCookie); err == nil {
+			chosen = cookie.Value
+		}
+	}
+	if slices.Contains(pageThemes, chosen) {
+		return chosen
+	}
+	return ""
+}
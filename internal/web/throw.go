@@ -0,0 +1,68 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"juggler/internal/juggler"
+)
+
+// ThrowRequest represents a manual throw; both fields are optional
+type ThrowRequest struct {
+	BallID int `json:"ball_id,omitempty"` // 0 throws the ball held the longest
+	Height int `json:"height,omitempty"`  // flight time in seconds, 0 picks one at random
+}
+
+// ThrowResponse describes the ball that was thrown
+type ThrowResponse struct {
+	BallID     int    `json:"ball_id"`
+	Hand       string `json:"hand"` // throwing hand
+	FlightTime int    `json:"flight_time"`
+	Message    string `json:"message"`
+}
+
+// throwConflicts maps the engine's throw errors to the keys of API messages
+var throwConflicts = map[error]string{
+	juggler.ErrNotRunning: "error.not_running",
+	juggler.ErrNotManual:  "error.not_manual",
+	juggler.ErrNotInHand:  "error.not_in_hand",
+	juggler.ErrNoBall:     "error.no_ball",
+	juggler.ErrPaused:     "error.paused",
+}
+
+// HandleThrow throws a ball in a session started in manual mode
+func (s *Server) HandleThrow(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req ThrowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	ball, err := s.juggler.Throw(req.BallID, req.Height)
+	switch {
+	case err == nil:
+	case errors.Is(err, juggler.ErrUnknownBall):
+		writeError(w, http.StatusNotFound, CodeNotFound, tr(r, "error.ball_not_found", req.BallID))
+		return
+	case errors.Is(err, juggler.ErrHeight):
+		writeError(w, http.StatusUnprocessableEntity, CodeValidationFailed, tr(r, "error.invalid_height"),
+			FieldError{Field: "height", Message: trErr(r, err)})
+		return
+	default:
+		writeError(w, http.StatusConflict, CodeConflict, tr(r, throwConflicts[err]))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ThrowResponse{
+		BallID:     ball.ID,
+		Hand:       ball.Hand,
+		FlightTime: ball.FlightTime,
+		Message:    tr(r, "status.thrown_"+ball.Hand, ball.ID, ball.FlightTime),
+	})
+}
@@ -0,0 +1,177 @@
+package test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// assetURL matches the versioned URLs of static files in a page
+var assetURL = regexp.MustCompile(`/static/home\.js\?v=[0-9a-f]+`)
+
+func getWith(handler http.Handler, path string, header map[string]string) *httptest.ResponseRecorder {
+	return authRequest(handler, http.MethodGet, path, "", "", nil, header)
+}
+
+func TestHomeLinksVersionedAssets(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	rr := getWith(handler, "/", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Expected the page not to be cached, got %q", got)
+	}
+	url := assetURL.FindString(rr.Body.String())
+	if url == "" {
+		t.Fatalf("Expected the page to link a versioned home.js: %s", rr.Body.String())
+	}
+
+	rr = getWith(handler, url, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for %s, got %d", url, rr.Code)
+	}
+	if got := rr.Header().Get("Content-Type"); !strings.Contains(got, "javascript") {
+		t.Errorf("Expected JavaScript, got %q", got)
+	}
+	if got := rr.Header().Get("Cache-Control"); !strings.Contains(got, "immutable") {
+		t.Errorf("Expected the versioned file to be cached for good, got %q", got)
+	}
+	if !strings.Contains(rr.Body.String(), "function updateStats") {
+		t.Error("Expected the page script")
+	}
+
+	rr = getWith(handler, "/static/home.js", nil)
+	if got := rr.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Expected an unversioned request to be revalidated, got %q", got)
+	}
+}
+
+func TestStaticETag(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	rr := getWith(handler, "/static/home.css", nil)
+	etag := rr.Header().Get("ETag")
+	if rr.Code != http.StatusOK || etag == "" {
+		t.Fatalf("Expected 200 with an ETag, got %d %q", rr.Code, etag)
+	}
+
+	rr = getWith(handler, "/static/home.css", map[string]string{"If-None-Match": etag})
+	if rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+		t.Errorf("Expected 304 without a body, got %d with %d bytes", rr.Code, rr.Body.Len())
+	}
+
+	rr = getWith(handler, "/static/home.css", map[string]string{"If-None-Match": `"stale"`})
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected 200 for a stale ETag, got %d", rr.Code)
+	}
+}
+
+func TestStaticGzip(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	plain := getWith(handler, "/static/home.js", nil)
+	rr := getWith(handler, "/static/home.js", map[string]string{"Accept-Encoding": "gzip, deflate"})
+	if got := rr.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Expected a gzip-compressed response, got %q", got)
+	}
+	if !strings.Contains(rr.Header().Get("Vary"), "Accept-Encoding") {
+		t.Error("Expected Vary: Accept-Encoding")
+	}
+	if rr.Header().Get("ETag") == plain.Header().Get("ETag") {
+		t.Error("Expected the compressed file to have its own ETag")
+	}
+
+	zr, err := gzip.NewReader(rr.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(body, plain.Body.Bytes()) {
+		t.Error("Expected the compressed file to decompress to the original")
+	}
+
+	rr = getWith(handler, "/", map[string]string{"Accept-Encoding": "gzip"})
+	if got := rr.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Errorf("Expected the page to be compressed too, got %q", got)
+	}
+
+	rr = getWith(handler, "/static/home.js", map[string]string{"Accept-Encoding": "gzip;q=0"})
+	if got := rr.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Expected no compression with q=0, got %q", got)
+	}
+}
+
+func TestStaticNotFound(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	for _, path := range []string{"/static/missing.js", "/static/../templates/home.html"} {
+		rr := getWith(handler, path, nil)
+		if rr.Code == http.StatusOK || strings.Contains(rr.Body.String(), "<html") {
+			t.Errorf("Expected %s not to be served, got %d", path, rr.Code)
+		}
+	}
+}
+
+func TestDevAssets(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"templates/home.html":  `<html><script src="{{asset "home.js"}}"></script>первая версия</html>`,
+		"templates/embed.html": `<html>{{.Theme}}</html>`,
+		"static/home.js":       `console.log(1)`,
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, handler, _ := newTestServer(t)
+	if err := s.SetDevAssets(dir); err != nil {
+		t.Fatalf("SetDevAssets: %v", err)
+	}
+
+	if body := getWith(handler, "/", nil).Body.String(); !strings.Contains(body, "первая версия") {
+		t.Fatalf("Expected the page from disk, got %s", body)
+	}
+	os.WriteFile(filepath.Join(dir, "templates", "home.html"), []byte(`<html>вторая версия</html>`), 0o644)
+	if body := getWith(handler, "/", nil).Body.String(); !strings.Contains(body, "вторая версия") {
+		t.Errorf("Expected the edited page on reload, got %s", body)
+	}
+
+	url := "/static/home.js?v=whatever"
+	os.WriteFile(filepath.Join(dir, "static", "home.js"), []byte(`console.log(2)`), 0o644)
+	rr := getWith(handler, url, nil)
+	if rr.Body.String() != `console.log(2)` {
+		t.Errorf("Expected the edited file, got %q", rr.Body.String())
+	}
+	if got := rr.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("Expected no caching in development, got %q", got)
+	}
+}
+
+func TestDevAssetsInvalidTemplates(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "templates"), 0o755)
+	os.WriteFile(filepath.Join(dir, "templates", "home.html"), []byte(`{{if}}`), 0o644)
+
+	s, _, _ := newTestServer(t)
+	if err := s.SetDevAssets(dir); err == nil {
+		t.Error("Expected an error for a template that does not parse")
+	}
+	if err := s.SetDevAssets(t.TempDir()); err == nil {
+		t.Error("Expected an error for a directory without templates")
+	}
+}
@@ -0,0 +1,371 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"juggler/client"
+	"juggler/internal/auth"
+	"juggler/internal/grpcapi"
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+// testAuthConfig has one token per role and a user who logs in on the page
+func testAuthConfig(t *testing.T) auth.Config {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("hunter2"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return auth.Config{
+		Tokens: []auth.Token{
+			{Name: "dashboard", Token: "view-token", Role: auth.Viewer},
+			{Name: "ci", Token: "operate-token", Role: auth.Operator},
+			{Name: "root", Token: "admin-token", Role: auth.Admin},
+		},
+		Users: []auth.User{
+			{Name: "alice", PasswordHash: string(hash), Role: auth.Operator},
+			{Name: "bob", Password: "secret", Role: auth.Viewer},
+		},
+	}
+}
+
+// withAuth makes a test server require authentication with testAuthConfig
+func withAuth() serverOption {
+	return func(t *testing.T, s *web.Server, j *juggler.Juggler) {
+		t.Helper()
+
+		a, err := auth.New(testAuthConfig(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.SetAuth(a)
+	}
+}
+
+// authRequest sends a request with the given bearer token and cookies
+func authRequest(handler http.Handler, method, path, token, body string, cookies []*http.Cookie, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+// errorCode returns the code of an error envelope
+func errorCode(rr *httptest.ResponseRecorder) string {
+	var resp web.ErrorResponse
+	json.Unmarshal(rr.Body.Bytes(), &resp)
+	return resp.Error.Code
+}
+
+func TestAuthRoles(t *testing.T) {
+	tests := []struct {
+		role     auth.Role
+		required auth.Role
+		allowed  bool
+	}{
+		{auth.Viewer, auth.Viewer, true},
+		{auth.Viewer, auth.Operator, false},
+		{auth.Operator, auth.Viewer, true},
+		{auth.Operator, auth.Admin, false},
+		{auth.Admin, auth.Operator, true},
+		{"", auth.Viewer, false},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Allows(tt.required); got != tt.allowed {
+			t.Errorf("Expected %q allows %q to be %v, got %v", tt.role, tt.required, tt.allowed, got)
+		}
+	}
+}
+
+func TestAuthConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config auth.Config
+	}{
+		{"Empty", auth.Config{}},
+		{"Token without role", auth.Config{Tokens: []auth.Token{{Token: "x"}}}},
+		{"Unknown role", auth.Config{Tokens: []auth.Token{{Token: "x", Role: "root"}}}},
+		{"User without password", auth.Config{Users: []auth.User{{Name: "a", Role: auth.Admin}}}},
+		{"User with both passwords", auth.Config{Users: []auth.User{{Name: "a", Password: "p", PasswordHash: "h", Role: auth.Admin}}}},
+		{"Duplicate user", auth.Config{Users: []auth.User{{Name: "a", Password: "p", Role: auth.Admin}, {Name: "a", Password: "q", Role: auth.Viewer}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := auth.New(tt.config); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestAuthLoginAndSessions(t *testing.T) {
+	a, err := auth.New(testAuthConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.Login("alice", "wrong"); err != auth.ErrBadCredentials {
+		t.Errorf("Expected ErrBadCredentials for a wrong password, got %v", err)
+	}
+	if _, err := a.Login("carol", "hunter2"); err != auth.ErrBadCredentials {
+		t.Errorf("Expected ErrBadCredentials for an unknown user, got %v", err)
+	}
+	if _, err := a.Login("bob", "secret"); err != nil {
+		t.Errorf("Expected a plain text password to work, got %v", err)
+	}
+
+	now := time.Now()
+	a.SetClock(func() time.Time { return now })
+	session, err := a.Login("alice", "hunter2")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if session.Identity.Role != auth.Operator || session.ID == "" || session.CSRF == "" || session.ID == session.CSRF {
+		t.Errorf("Unexpected session %+v", session)
+	}
+
+	if _, err := a.Session(session.ID); err != nil {
+		t.Errorf("Expected the session to be live, got %v", err)
+	}
+	now = now.Add(auth.SessionTTL)
+	if _, err := a.Session(session.ID); err != auth.ErrNoSession {
+		t.Errorf("Expected the session to expire, got %v", err)
+	}
+}
+
+func TestAuthCheckToken(t *testing.T) {
+	a, err := auth.New(testAuthConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for token, name := range map[string]string{"view-token": "dashboard", "operate-token": "ci", "admin-token": "root"} {
+		if id, ok := a.CheckToken(token); !ok || id.Name != name {
+			t.Errorf("Expected %s to be the token of %s, got %+v %v", token, name, id, ok)
+		}
+	}
+	for _, token := range []string{"", "view", "view-token-", "VIEW-TOKEN"} {
+		if id, ok := a.CheckToken(token); ok {
+			t.Errorf("Expected %q to be refused, got %+v", token, id)
+		}
+	}
+
+	if names := a.PlainPasswords(); len(names) != 1 || names[0] != "bob" {
+		t.Errorf("Expected only bob to have a plain text password, got %v", names)
+	}
+}
+
+func TestAuthLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	os.WriteFile(path, []byte(`{"tokens": [{"name": "ci", "token": "t", "role": "operator"}]}`), 0o600)
+
+	a, err := auth.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if id, ok := a.CheckToken("t"); !ok || id.Name != "ci" || id.Role != auth.Operator {
+		t.Errorf("Expected the token of ci, got %+v %v", id, ok)
+	}
+
+	os.WriteFile(path, []byte(`{"tokens": [{"token": "t", "role": "god"}]}`), 0o600)
+	if _, err := auth.Load(path); err == nil {
+		t.Error("Expected an error for an unknown role")
+	}
+}
+
+func TestWebServerAuthTokens(t *testing.T) {
+	_, handler, j := newTestServer(t, withAuth())
+	start := `{"total_balls": 2, "time_minutes": 1}`
+
+	rr := authRequest(handler, "GET", "/api/v1/stats", "", "", nil, nil)
+	if rr.Code != http.StatusUnauthorized || errorCode(rr) != web.CodeUnauthorized {
+		t.Errorf("Expected 401 without credentials, got %d %s", rr.Code, rr.Body.String())
+	}
+	if rr.Header().Get("WWW-Authenticate") == "" {
+		t.Error("Expected a WWW-Authenticate header")
+	}
+	if rr := authRequest(handler, "GET", "/api/stats", "wrong", "", nil, nil); rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an unknown token on the legacy path, got %d", rr.Code)
+	}
+
+	if rr := authRequest(handler, "GET", "/api/v1/stats", "view-token", "", nil, nil); rr.Code != http.StatusOK {
+		t.Errorf("Expected a viewer to read stats, got %d", rr.Code)
+	}
+	rr = authRequest(handler, "POST", "/api/v1/start", "view-token", start, nil, nil)
+	if rr.Code != http.StatusForbidden || errorCode(rr) != web.CodeForbidden {
+		t.Errorf("Expected 403 for a viewer starting, got %d %s", rr.Code, rr.Body.String())
+	}
+
+	if rr := authRequest(handler, "POST", "/api/v1/start", "operate-token", start, nil, nil); rr.Code != http.StatusOK {
+		t.Errorf("Expected an operator to start, got %d %s", rr.Code, rr.Body.String())
+	}
+	if !j.IsRunning() {
+		t.Error("Expected the juggler to run")
+	}
+
+	options := `{"min_flight_time": 2, "max_flight_time": 4, "throw_interval_ms": 250, "drop_chance": 0.1}`
+	if rr := authRequest(handler, "PUT", "/api/v1/options", "operate-token", options, nil, nil); rr.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for an operator changing options, got %d", rr.Code)
+	}
+	rr = authRequest(handler, "PUT", "/api/v1/options", "admin-token", options, nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected an admin to change options, got %d %s", rr.Code, rr.Body.String())
+	}
+	if o := j.GetOptions(); o.MinFlightTime != 2 || o.MaxFlightTime != 4 || o.ThrowInterval != 250*time.Millisecond || o.DropChance != 0.1 {
+		t.Errorf("Expected the new options, got %+v", o)
+	}
+
+	// The page and the spec stay open
+	for _, path := range []string{"/", "/api/v1/openapi.json", "/api/v1/auth/status"} {
+		if rr := authRequest(handler, "GET", path, "", "", nil, nil); rr.Code != http.StatusOK {
+			t.Errorf("Expected %s to be open, got %d", path, rr.Code)
+		}
+	}
+}
+
+func TestWebServerAuthLogin(t *testing.T) {
+	_, handler, _ := newTestServer(t, withAuth())
+
+	rr := authRequest(handler, "POST", "/api/v1/auth/login", "", `{"name": "alice", "password": "nope"}`, nil, nil)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a wrong password, got %d", rr.Code)
+	}
+
+	rr = authRequest(handler, "POST", "/api/v1/auth/login", "", `{"name": "alice", "password": "hunter2"}`, nil, nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected login to succeed, got %d %s", rr.Code, rr.Body.String())
+	}
+	var login web.AuthStatus
+	json.Unmarshal(rr.Body.Bytes(), &login)
+	if login.Name != "alice" || login.Role != auth.Operator || login.CSRFToken == "" {
+		t.Errorf("Unexpected login response %+v", login)
+	}
+	cookies := rr.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != web.SessionCookie || !cookies[0].HttpOnly || cookies[0].SameSite != http.SameSiteStrictMode {
+		t.Fatalf("Expected an HttpOnly SameSite session cookie, got %+v", cookies)
+	}
+
+	var status web.AuthStatus
+	rr = authRequest(handler, "GET", "/api/v1/auth/status", "", "", cookies, nil)
+	json.Unmarshal(rr.Body.Bytes(), &status)
+	if !status.Enabled || status.Name != "alice" || status.CSRFToken != login.CSRFToken {
+		t.Errorf("Expected the status of alice's session, got %+v", status)
+	}
+
+	if rr := authRequest(handler, "GET", "/api/v1/stats", "", "", cookies, nil); rr.Code != http.StatusOK {
+		t.Errorf("Expected the cookie to allow reading without a CSRF token, got %d", rr.Code)
+	}
+
+	start := `{"total_balls": 2, "time_minutes": 1}`
+	rr = authRequest(handler, "POST", "/api/v1/start", "", start, cookies, nil)
+	if rr.Code != http.StatusForbidden || errorCode(rr) != web.CodeCSRFFailed {
+		t.Errorf("Expected 403 csrf_failed without the token, got %d %s", rr.Code, rr.Body.String())
+	}
+	rr = authRequest(handler, "POST", "/api/v1/start", "", start, cookies, map[string]string{web.CSRFHeader: "forged"})
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 with a wrong token, got %d", rr.Code)
+	}
+	rr = authRequest(handler, "POST", "/api/v1/start", "", start, cookies, map[string]string{web.CSRFHeader: login.CSRFToken})
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected the start to succeed with the token, got %d %s", rr.Code, rr.Body.String())
+	}
+
+	if rr := authRequest(handler, "POST", "/api/v1/auth/logout", "", "", cookies, nil); rr.Code != http.StatusForbidden {
+		t.Errorf("Expected logout without the CSRF token to be refused, got %d", rr.Code)
+	}
+	rr = authRequest(handler, "POST", "/api/v1/auth/logout", "", "", cookies, map[string]string{web.CSRFHeader: login.CSRFToken})
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected logout to succeed, got %d", rr.Code)
+	}
+	if rr := authRequest(handler, "GET", "/api/v1/stats", "", "", cookies, nil); rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected the session to end on logout, got %d", rr.Code)
+	}
+}
+
+func TestWebServerAuthDisabled(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+
+	var status web.AuthStatus
+	rr := authRequest(handler, "GET", "/api/v1/auth/status", "", "", nil, nil)
+	json.Unmarshal(rr.Body.Bytes(), &status)
+	if status.Enabled {
+		t.Error("Expected authentication to be off")
+	}
+	if rr := authRequest(handler, "POST", "/api/v1/auth/login", "", `{"name": "a", "password": "b"}`, nil, nil); rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a login without auth, got %d", rr.Code)
+	}
+	if rr := authRequest(handler, "GET", "/api/v1/options", "", "", nil, nil); rr.Code != http.StatusOK {
+		t.Errorf("Expected options to be open, got %d", rr.Code)
+	}
+	if rr := authRequest(handler, "PUT", "/api/v1/options", "", `{"min_flight_time": 0}`, nil, nil); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for invalid options, got %d", rr.Code)
+	}
+}
+
+func TestClientToken(t *testing.T) {
+	_, handler, _ := newTestServer(t, withAuth())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	if _, err := client.New(server.URL).Stats(context.Background()); err == nil {
+		t.Error("Expected an error without a token")
+	}
+	if _, err := client.New(server.URL, client.WithToken("view-token")).Stats(context.Background()); err != nil {
+		t.Errorf("Expected the token to be accepted, got %v", err)
+	}
+}
+
+func TestGRPCAuth(t *testing.T) {
+	s, _, j := newTestServer(t, withAuth())
+	a, _ := auth.New(testAuthConfig(t))
+	service := grpcapi.NewService(s, j)
+	service.SetAuth(a)
+	c := newGRPCClientFor(t, service)
+	ctx := context.Background()
+
+	if _, err := c.GetStats(ctx); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a token, got %v", err)
+	}
+
+	viewer := newGRPCClientFor(t, service, grpcapi.TokenCredentials("view-token"))
+	if _, err := viewer.GetStats(ctx); err != nil {
+		t.Errorf("Expected a viewer to read stats, got %v", err)
+	}
+	if _, err := viewer.Start(ctx, web.StartRequest{TotalBalls: 1, TimeMinutes: 1}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for a viewer starting, got %v", err)
+	}
+
+	operator := newGRPCClientFor(t, service, grpcapi.TokenCredentials("operate-token"))
+	if _, err := operator.Start(ctx, web.StartRequest{TotalBalls: 1, TimeMinutes: 1}); err != nil {
+		t.Errorf("Expected an operator to start, got %v", err)
+	}
+}
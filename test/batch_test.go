@@ -0,0 +1,314 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/batch"
+	"juggler/internal/juggler"
+)
+
+func TestSimulateVirtualTime(t *testing.T) {
+	opts := juggler.Options{
+		MinFlightTime: 5,
+		MaxFlightTime: 5,
+		ThrowInterval: 500 * time.Millisecond,
+	}
+
+	start := time.Now()
+	summary, err := batch.Simulate(1, 1, opts, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if time.Since(start) > time.Second {
+		t.Errorf("Expected virtual session to finish quickly, took %v", time.Since(start))
+	}
+
+	// Thrown at 0.5s and caught 5s later on a throw tick, where it is
+	// rethrown at once: throws at 0.5s, 5.5s, ..., 55.5s
+	if summary.Throws != 12 {
+		t.Errorf("Expected 12 throws, got %d", summary.Throws)
+	}
+
+	if summary.Catches != 12 || summary.Drops != 0 {
+		t.Errorf("Expected 12 catches and no drops, got %d catches, %d drops", summary.Catches, summary.Drops)
+	}
+
+	if summary.LongestStreak != 12 {
+		t.Errorf("Expected longest streak of 12, got %d", summary.LongestStreak)
+	}
+}
+
+func TestSimulateAlwaysDrop(t *testing.T) {
+	opts := juggler.DefaultOptions()
+	opts.DropChance = 1
+
+	summary, err := batch.Simulate(4, 1, opts, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Throws != 4 || summary.Drops != 4 || summary.Catches != 0 {
+		t.Errorf("Expected every ball thrown and dropped once, got %d throws, %d drops, %d catches",
+			summary.Throws, summary.Drops, summary.Catches)
+	}
+}
+
+// simulate plays a session of balls for the given minutes with the options
+// and ball definitions on a seeded virtual clock and returns the juggler
+// and its clock
+func simulate(t *testing.T, opts juggler.Options, balls, minutes int, defs ...juggler.BallDef) (*juggler.Juggler, *juggler.VirtualClock) {
+	t.Helper()
+
+	clock := juggler.NewVirtualClock(time.Date(2025, 7, 8, 12, 0, 0, 0, time.UTC))
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetClock(clock)
+	j.SetSeed(1)
+	if err := j.SetOptions(opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.SetBallDefs(defs); err != nil {
+		t.Fatal(err)
+	}
+	j.Reset(balls, minutes)
+	j.Simulate(clock)
+	return j, clock
+}
+
+func TestSimulateTwice(t *testing.T) {
+	j, clock := simulate(t, juggler.DefaultOptions(), 3, 1)
+	events := len(j.GetEvents())
+
+	// A second run of the same session must neither replay it nor close Done twice
+	j.Simulate(clock)
+	if got := len(j.GetEvents()); got != events {
+		t.Errorf("Expected the finished session to stay at %d events, got %d", events, got)
+	}
+	select {
+	case <-j.Done():
+	default:
+		t.Error("Expected Done to be closed")
+	}
+}
+
+func TestBatchRunReproducible(t *testing.T) {
+	p := batch.Params{
+		Balls:   5,
+		Minutes: 2,
+		Runs:    50,
+		Seed:    42,
+		Options: juggler.DefaultOptions(),
+	}
+	p.Options.DropChance = 0.02
+
+	first, err := batch.Run(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := batch.Run(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first.Metrics) != len(second.Metrics) {
+		t.Fatalf("Expected same number of metrics, got %d and %d", len(first.Metrics), len(second.Metrics))
+	}
+
+	for i := range first.Metrics {
+		if first.Metrics[i] != second.Metrics[i] {
+			t.Errorf("Expected identical metric %s for same seed, got %+v and %+v",
+				first.Metrics[i].Name, first.Metrics[i], second.Metrics[i])
+		}
+	}
+
+	if first.DropRate != second.DropRate {
+		t.Errorf("Expected identical drop rate, got %f and %f", first.DropRate, second.DropRate)
+	}
+}
+
+func TestBatchRunInvalidParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params batch.Params
+	}{
+		{"Zero runs", batch.Params{Balls: 3, Minutes: 1, Runs: 0, Options: juggler.DefaultOptions()}},
+		{"Zero balls", batch.Params{Balls: 0, Minutes: 1, Runs: 1, Options: juggler.DefaultOptions()}},
+		{"Bad flight range", batch.Params{Balls: 3, Minutes: 1, Runs: 1, Options: juggler.Options{
+			MinFlightTime: 6, MaxFlightTime: 5, ThrowInterval: time.Second,
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := batch.Run(tt.params); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	values := make([]float64, 0, 100)
+	for i := 100; i >= 1; i-- {
+		values = append(values, float64(i))
+	}
+
+	m := batch.Distribution("x", values)
+
+	if m.Mean != 50.5 {
+		t.Errorf("Expected mean 50.5, got %f", m.Mean)
+	}
+	if m.Min != 1 || m.Max != 100 {
+		t.Errorf("Expected min 1 and max 100, got %f and %f", m.Min, m.Max)
+	}
+	if m.P50 != 50 || m.P90 != 90 || m.P99 != 99 {
+		t.Errorf("Expected p50/p90/p99 of 50/90/99, got %f/%f/%f", m.P50, m.P90, m.P99)
+	}
+}
+
+func TestReportFormats(t *testing.T) {
+	p := batch.Params{Balls: 3, Minutes: 1, Runs: 5, Seed: 1, Options: juggler.DefaultOptions()}
+	report, err := batch.Run(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var text bytes.Buffer
+	if err := report.Write(&text, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), "longest_streak") {
+		t.Errorf("Expected text report to list longest_streak, got %q", text.String())
+	}
+
+	var js bytes.Buffer
+	if err := report.Write(&js, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded batch.Report
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to parse JSON report: %v", err)
+	}
+	if decoded.Params.Runs != 5 || len(decoded.Metrics) != len(report.Metrics) {
+		t.Errorf("Expected JSON report to round-trip, got %+v", decoded)
+	}
+
+	var csv bytes.Buffer
+	if err := report.Write(&csv, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if lines[0] != "metric,mean,min,p50,p90,p99,max" {
+		t.Errorf("Unexpected CSV header %q", lines[0])
+	}
+	if len(lines) != len(report.Metrics)+2 {
+		t.Errorf("Expected %d CSV lines, got %d", len(report.Metrics)+2, len(lines))
+	}
+
+	if err := report.Write(&csv, "xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestRunSweep(t *testing.T) {
+	g := batch.Grid{
+		Balls:          []int{1, 2, 3},
+		FlightRanges:   []batch.FlightRange{{Min: 5, Max: 10}},
+		ThrowIntervals: []time.Duration{500 * time.Millisecond, time.Second},
+		DropChances:    []float64{0, 0.05},
+		Minutes:        1,
+		Runs:           5,
+		Seed:           1,
+	}
+
+	sweep, err := batch.RunSweep(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sweep.Rows) != 12 {
+		t.Fatalf("Expected 12 rows, got %d", len(sweep.Rows))
+	}
+
+	for _, r := range sweep.Rows {
+		if r.DropChance == 0 && r.DropRate != 0 {
+			t.Errorf("Expected no drops without drop chance, got drop rate %f for %+v", r.DropRate, r)
+		}
+		if r.AvgInAir <= 0 || r.AvgInAir > float64(r.Balls) {
+			t.Errorf("Expected average in air between 0 and %d, got %f", r.Balls, r.AvgInAir)
+		}
+	}
+
+	var csv bytes.Buffer
+	if err := sweep.Write(&csv, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(csv.String()), "\n"); len(lines) != 13 {
+		t.Errorf("Expected header and 12 CSV rows, got %d lines", len(lines))
+	}
+
+	for _, cm := range batch.ChartMetrics {
+		var svg bytes.Buffer
+		if err := sweep.WriteSVG(&svg, cm.Name); err != nil {
+			t.Fatalf("WriteSVG(%s): %v", cm.Name, err)
+		}
+		if !strings.HasPrefix(svg.String(), "<svg") || !strings.Contains(svg.String(), "<polyline") {
+			t.Errorf("Expected SVG line chart for %s", cm.Name)
+		}
+	}
+
+	if err := sweep.WriteSVG(&csv, "nonsense"); err == nil {
+		t.Error("Expected error for unknown chart metric")
+	}
+}
+
+func TestSweepLimits(t *testing.T) {
+	g := batch.Grid{
+		Balls:          []int{1, 2, 3},
+		FlightRanges:   []batch.FlightRange{{Min: 5, Max: 10}},
+		ThrowIntervals: []time.Duration{time.Second},
+		DropChances:    []float64{0},
+		Minutes:        1,
+		Runs:           1000000,
+	}
+
+	if _, err := batch.RunSweep(g); err == nil {
+		t.Error("Expected error for a sweep exceeding the session limit")
+	}
+
+	g.Runs = 1
+	g.DropChances = nil
+	if _, err := batch.RunSweep(g); err == nil {
+		t.Error("Expected error for a sweep with an empty parameter list")
+	}
+}
+
+func TestRunSweepCancelled(t *testing.T) {
+	g := batch.Grid{
+		Balls:          []int{1, 2, 3},
+		FlightRanges:   []batch.FlightRange{{Min: 5, Max: 10}},
+		ThrowIntervals: []time.Duration{time.Second},
+		DropChances:    []float64{0},
+		Minutes:        60,
+		Runs:           1000,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	started := time.Now()
+	if _, err := batch.RunSweepContext(ctx, g); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled sweep to stop with context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("Expected the cancelled sweep to stop at once, took %v", elapsed)
+	}
+}
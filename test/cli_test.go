@@ -0,0 +1,121 @@
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/cli"
+	"juggler/internal/juggler"
+)
+
+func TestCLIHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := cli.Run([]string{"help"}, &stdout, &stderr); code != 0 {
+		t.Errorf("Expected exit code 0, got %d", code)
+	}
+
+	for _, name := range []string{"serve", "run", "replay", "validate", "bench"} {
+		if !strings.Contains(stdout.String(), name) {
+			t.Errorf("Expected help to mention %q command", name)
+		}
+	}
+}
+
+func TestCLICommandHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := cli.Run([]string{"run", "--help"}, &stdout, &stderr); code != 0 {
+		t.Errorf("Expected exit code 0, got %d", code)
+	}
+
+	if !strings.Contains(stderr.String(), "Usage: juggler run") {
+		t.Errorf("Expected run usage, got %q", stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "-balls") {
+		t.Errorf("Expected usage to list -balls flag, got %q", stderr.String())
+	}
+}
+
+func TestCLIUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := cli.Run([]string{"juggle"}, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected exit code 2, got %d", code)
+	}
+}
+
+func TestCLIValidate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := cli.Run([]string{"validate", "531"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	if !strings.Contains(stdout.String(), "Balls: 3") {
+		t.Errorf("Expected output to contain ball count, got %q", stdout.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+
+	if code := cli.Run([]string{"validate", "532"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1 for invalid siteswap, got %d", code)
+	}
+
+	if code := cli.Run([]string{"validate"}, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected exit code 2 without a pattern, got %d", code)
+	}
+}
+
+func TestCLIRunInvalidArgs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := cli.Run([]string{"run", "--balls", "0"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if code := cli.Run([]string{"run", "--bell", "throw,juggle"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1 for an unknown bell event, got %d", code)
+	}
+}
+
+func TestCLIReplay(t *testing.T) {
+	start := time.Date(2025, 7, 8, 12, 0, 0, 0, time.UTC)
+	events := []juggler.Event{
+		{Time: start, BallID: 1, Type: "throw", FlightTime: 5},
+		{Time: start.Add(time.Second), BallID: 2, Type: "throw", FlightTime: 7},
+		{Time: start.Add(5 * time.Second), BallID: 1, Type: "catch"},
+		{Time: start.Add(8 * time.Second), BallID: 2, Type: "catch"},
+	}
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := juggler.WriteEvents(f, events); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	var stdout, stderr bytes.Buffer
+	if code := cli.Run([]string{"replay", path}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, "Ball 2 thrown (7 sec flight)") {
+		t.Errorf("Expected replay to list throw of ball 2, got %q", out)
+	}
+	if !strings.Contains(out, "Throws: 2") || !strings.Contains(out, "Catches: 2") {
+		t.Errorf("Expected summary with 2 throws and 2 catches, got %q", out)
+	}
+	if !strings.Contains(out, "Duration: 8 seconds") {
+		t.Errorf("Expected duration of 8 seconds, got %q", out)
+	}
+}
@@ -0,0 +1,311 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"juggler/client"
+	"juggler/internal/auth"
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+func TestClientAgainstServer(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	ts := httptest.NewServer(web.NewServer(j, 8080).Handler())
+	defer ts.Close()
+
+	c := client.New(ts.URL)
+	ctx := context.Background()
+
+	started, err := c.Start(ctx, client.StartRequest{TotalBalls: 3, TimeMinutes: 1})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if started.Status != "started" {
+		t.Errorf("Expected status 'started', got %s", started.Status)
+	}
+	if started.Session == nil || started.Session.ID != j.GetSession() {
+		t.Errorf("Expected session %d in the response, got %+v", j.GetSession(), started.Session)
+	}
+
+	stats, err := c.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.TotalBalls != 3 || len(stats.Balls) != 3 {
+		t.Errorf("Expected 3 balls, got %d with %d details", stats.TotalBalls, len(stats.Balls))
+	}
+
+	stopped, err := c.Stop(ctx)
+	if err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if stopped.Status != "stopped" {
+		t.Errorf("Expected status 'stopped', got %s", stopped.Status)
+	}
+}
+
+func TestClientDecodesErrors(t *testing.T) {
+	ts := httptest.NewServer(web.NewServer(juggler.NewJuggler(0, 0), 8080).Handler())
+	defer ts.Close()
+
+	_, err := client.New(ts.URL).Start(context.Background(), client.StartRequest{TotalBalls: 0, TimeMinutes: 1})
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnprocessableEntity, apiErr.StatusCode)
+	}
+	if apiErr.Code != "validation_failed" || apiErr.Message == "" {
+		t.Errorf("Expected validation_failed with a message, got %q: %q", apiErr.Code, apiErr.Message)
+	}
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "total_balls" {
+		t.Errorf("Expected one detail for total_balls, got %+v", apiErr.Details)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	var calls atomic.Int32
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("Idempotency-Key"); key != "" {
+			keys = append(keys, key)
+		}
+		if calls.Add(1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status": "stopped", "message": "Juggling stopped"}`))
+	}))
+	defer ts.Close()
+
+	c := client.New(ts.URL, client.WithRetries(3, time.Millisecond))
+
+	if _, err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("Expected Stop to succeed after retries, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+
+	calls.Store(0)
+	if _, err := c.Start(context.Background(), client.StartRequest{TotalBalls: 3, TimeMinutes: 1}); err != nil {
+		t.Fatalf("Expected Start to succeed after retries, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+	if len(keys) != 3 || keys[0] == "" || keys[0] != keys[1] || keys[1] != keys[2] {
+		t.Errorf("Expected every attempt to carry the same Idempotency-Key, got %q", keys)
+	}
+}
+
+func TestClientContextCancel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := client.New(ts.URL, client.WithRetries(10, time.Second))
+	if _, err := c.Stats(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestClientBalls(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.Reset(2, 1)
+	ts := httptest.NewServer(web.NewServer(j, 8080).Handler())
+	defer ts.Close()
+
+	c := client.New(ts.URL)
+	ctx := context.Background()
+
+	added, err := c.AddBall(ctx)
+	if err != nil {
+		t.Fatalf("AddBall: %v", err)
+	}
+	if added.ID != 3 || added.TotalBalls != 3 {
+		t.Errorf("Expected ball 3 of 3, got %+v", added)
+	}
+
+	removed, err := c.RemoveBall(ctx, 1)
+	if err != nil {
+		t.Fatalf("RemoveBall: %v", err)
+	}
+	if removed.Status != "removed" || removed.TotalBalls != 2 {
+		t.Errorf("Expected ball 1 removed leaving 2 balls, got %+v", removed)
+	}
+}
+
+func TestClientLeaderboard(t *testing.T) {
+	ts := httptest.NewServer(web.NewServer(juggler.NewJuggler(0, 0), 8080).Handler())
+	defer ts.Close()
+
+	c := client.New(ts.URL)
+	ctx := context.Background()
+
+	if _, err := c.AddPlayer(ctx, "Alice"); err != nil {
+		t.Fatalf("AddPlayer: %v", err)
+	}
+	var apiErr *client.APIError
+	if _, err := c.AddPlayer(ctx, "alice"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("Expected a conflict for a duplicate player, got %v", err)
+	}
+
+	players, err := c.Players(ctx)
+	if err != nil || len(players) != 1 || players[0].Name != "Alice" {
+		t.Errorf("Expected Alice to be listed, got %+v %v", players, err)
+	}
+
+	board, err := c.Leaderboard(ctx, "catches", 3, 5)
+	if err != nil {
+		t.Fatalf("Leaderboard: %v", err)
+	}
+	if board.Metric != "catches" || board.Balls != 3 || len(board.Entries) != 0 {
+		t.Errorf("Expected an empty catches leaderboard for 3 balls, got %+v", board)
+	}
+}
+
+// TestClientCoversRoutes calls every client method through a login
+// session and fails for any API route none of them reached, so that a new
+// route cannot be left out of the client
+func TestClientCoversRoutes(t *testing.T) {
+	cfg := testAuthConfig(t)
+	cfg.Users = append(cfg.Users, auth.User{Name: "root", Password: "toor", Role: auth.Admin})
+	a, err := auth.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetOptions(juggler.Options{MinFlightTime: 1, MaxFlightTime: 1, ThrowInterval: 50 * time.Millisecond})
+	t.Cleanup(j.Stop)
+	s := web.NewServer(j, 8080)
+	s.SetAuth(a)
+
+	var mu sync.Mutex
+	reached := make(map[string]bool)
+	handler := s.Handler()
+	mux := http.NewServeMux()
+	mux.Handle("/", handler)
+	for _, route := range s.Routes() {
+		key := route.Method + " " + route.Path
+		mux.HandleFunc(key, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			reached[key] = true
+			mu.Unlock()
+			handler.ServeHTTP(w, r)
+		})
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := client.New(ts.URL)
+	ctx := context.Background()
+	check := func(what string, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: %v", what, err)
+		}
+	}
+
+	status, err := c.AuthStatus(ctx)
+	check("AuthStatus", err)
+	if !status.Enabled || status.Name != "" {
+		t.Errorf("Expected authentication on and no caller yet, got %+v", status)
+	}
+	status, err = c.Login(ctx, "root", "toor")
+	check("Login", err)
+	if status.Role != "admin" || status.CSRFToken == "" {
+		t.Errorf("Expected an admin session with a CSRF token, got %+v", status)
+	}
+
+	opts, err := c.Options(ctx)
+	check("Options", err)
+	opts.DropChance = 0
+	_, err = c.SetOptions(ctx, *opts)
+	check("SetOptions", err)
+
+	stream, err := c.WatchEvents(ctx, 0)
+	check("WatchEvents", err)
+	defer stream.Close()
+
+	_, err = c.Start(ctx, client.StartRequest{TotalBalls: 2, TimeMinutes: 1, Manual: true})
+	check("Start", err)
+	_, err = c.Throw(ctx, client.ThrowRequest{})
+	check("Throw", err)
+	msg, err := stream.Recv()
+	check("Recv", err)
+	if msg.Type != "throw" || msg.Session != j.GetSession() {
+		t.Errorf("Expected the throw of session %d, got %+v", j.GetSession(), msg)
+	}
+	_, err = c.Stats(ctx)
+	check("Stats", err)
+	_, err = c.AddBall(ctx)
+	check("AddBall", err)
+	_, err = c.RemoveBall(ctx, 3)
+	check("RemoveBall", err)
+	events, err := c.Events(ctx, 0)
+	check("Events", err)
+	if len(events.Events) == 0 {
+		t.Error("Expected the throw among the events")
+	}
+	_, err = c.Export(ctx)
+	check("Export", err)
+	csv, err := c.ExportTable(ctx, "csv", "balls")
+	check("ExportTable", err)
+	if !strings.HasPrefix(string(csv), "ball_id,") {
+		t.Errorf("Expected a CSV table of balls, got %q", csv)
+	}
+	_, err = c.Stop(ctx)
+	check("Stop", err)
+
+	_, err = c.AddPlayer(ctx, "Alice")
+	check("AddPlayer", err)
+	_, err = c.Players(ctx)
+	check("Players", err)
+	_, err = c.Leaderboard(ctx, "", 0, 0)
+	check("Leaderboard", err)
+
+	exp, err := c.RunExperiment(ctx, client.ExperimentRequest{Balls: []int{1}, Minutes: 1, Runs: 1})
+	check("RunExperiment", err)
+	_, err = c.Experiment(ctx, exp.ID, "csv")
+	check("Experiment", err)
+	svg, err := c.ExperimentChart(ctx, exp.ID, "drop_rate")
+	check("ExperimentChart", err)
+	if !strings.Contains(string(svg), "<svg") {
+		t.Errorf("Expected an SVG chart, got %q", svg)
+	}
+
+	_, err = c.OpenAPI(ctx)
+	check("OpenAPI", err)
+	_, err = c.Logout(ctx)
+	check("Logout", err)
+	var apiErr *client.APIError
+	if _, err := c.Stats(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 after logging out, got %v", err)
+	}
+
+	for _, route := range s.Routes() {
+		if key := route.Method + " " + route.Path; !reached[key] {
+			t.Errorf("No client method calls %s", key)
+		}
+	}
+}
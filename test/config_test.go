@@ -12,61 +12,11 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.WebPort != 8080 {
 		t.Errorf("Expected WebPort to be 8080, got %d", cfg.WebPort)
 	}
-}
-
-func TestLoadFromArgs(t *testing.T) {
-	tests := []struct {
-		name         string
-		args         []string
-		expectedPort int
-		expectError  bool
-	}{
-		{
-			name:         "No arguments",
-			args:         []string{"program"},
-			expectedPort: 8080,
-			expectError:  false,
-		},
-		{
-			name:         "Valid port",
-			args:         []string{"program", "9000"},
-			expectedPort: 9000,
-			expectError:  false,
-		},
-		{
-			name:         "Invalid port format",
-			args:         []string{"program", "abc"},
-			expectedPort: 0,
-			expectError:  true,
-		},
-		{
-			name:         "Multiple arguments",
-			args:         []string{"program", "3000", "extra"},
-			expectedPort: 3000,
-			expectError:  false,
-		},
+	if cfg.GRPCPort != 0 {
+		t.Errorf("Expected the gRPC API to be off by default, got port %d", cfg.GRPCPort)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			cfg, err := config.LoadFromArgs(tt.args)
-
-			if tt.expectError {
-				if err == nil {
-					t.Errorf("Expected error but got none")
-				}
-				return
-			}
-
-			if err != nil {
-				t.Errorf("Unexpected error: %v", err)
-				return
-			}
-
-			if cfg.WebPort != tt.expectedPort {
-				t.Errorf("Expected WebPort to be %d, got %d", tt.expectedPort, cfg.WebPort)
-			}
-		})
+	if cfg.LeaderboardFile != "" {
+		t.Errorf("Expected the leaderboard to stay in memory by default, got %q", cfg.LeaderboardFile)
 	}
 }
 
@@ -104,6 +54,62 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Invalid gRPC port",
+			config: &config.Config{
+				WebPort:  8080,
+				GRPCPort: 70000,
+			},
+			expectError: true,
+		},
+		{
+			name: "Negative rate limit",
+			config: &config.Config{
+				WebPort:   8080,
+				RateLimit: -1,
+			},
+			expectError: true,
+		},
+		{
+			name: "Rate limit without burst",
+			config: &config.Config{
+				WebPort:   8080,
+				RateLimit: 5,
+			},
+			expectError: true,
+		},
+		{
+			name: "CORS origins",
+			config: &config.Config{
+				WebPort:     8080,
+				CORSOrigins: []string{"https://portal.example.com", "http://localhost:3000", "*"},
+			},
+			expectError: false,
+		},
+		{
+			name: "CORS origin with a path",
+			config: &config.Config{
+				WebPort:     8080,
+				CORSOrigins: []string{"https://portal.example.com/app"},
+			},
+			expectError: true,
+		},
+		{
+			name: "Missing dev assets directory",
+			config: &config.Config{
+				WebPort:   8080,
+				DevAssets: "no-such-directory",
+			},
+			expectError: true,
+		},
+		{
+			name: "gRPC port same as web port",
+			config: &config.Config{
+				WebPort:  8080,
+				GRPCPort: 8080,
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
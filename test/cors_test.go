@@ -0,0 +1,163 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+const portalOrigin = "https://portal.example.com"
+
+// withCORS lets a test server be called from the given origins
+func withCORS(origins ...string) serverOption {
+	return func(t *testing.T, s *web.Server, j *juggler.Juggler) {
+		s.SetCORS(web.DefaultCORSOptions(origins...))
+	}
+}
+
+func TestCORSAllowedOrigin(t *testing.T) {
+	_, handler, _ := newTestServer(t, withCORS(portalOrigin))
+
+	rr := authRequest(handler, http.MethodGet, "/api/stats", "", "", nil, map[string]string{"Origin": portalOrigin})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != portalOrigin {
+		t.Errorf("Expected Access-Control-Allow-Origin %q, got %q", portalOrigin, got)
+	}
+	if got := rr.Header().Get("Access-Control-Expose-Headers"); !strings.Contains(got, "Retry-After") {
+		t.Errorf("Expected Retry-After to be exposed, got %q", got)
+	}
+	if got := rr.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("Expected Vary: Origin, got %q", got)
+	}
+	if rr.Header().Get("Access-Control-Allow-Credentials") != "" {
+		t.Error("Expected credentials not to be allowed")
+	}
+}
+
+func TestCORSOtherOrigin(t *testing.T) {
+	_, handler, _ := newTestServer(t, withCORS(portalOrigin))
+
+	rr := authRequest(handler, http.MethodGet, "/api/v1/stats", "", "", nil, map[string]string{"Origin": "https://evil.example.com"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no Access-Control-Allow-Origin, got %q", got)
+	}
+
+	rr = authRequest(handler, http.MethodOptions, "/api/v1/start", "", "", nil, map[string]string{
+		"Origin":                        "https://evil.example.com",
+		"Access-Control-Request-Method": http.MethodPost,
+	})
+	if rr.Code != http.StatusForbidden || errorCode(rr) != web.CodeForbidden {
+		t.Errorf("Expected preflight from another origin to get 403 forbidden, got %d %s", rr.Code, errorCode(rr))
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	_, handler, _ := newTestServer(t, withCORS(portalOrigin))
+
+	rr := authRequest(handler, http.MethodOptions, "/api/v1/start", "", "", nil, map[string]string{
+		"Origin":                         portalOrigin,
+		"Access-Control-Request-Method":  http.MethodPost,
+		"Access-Control-Request-Headers": "content-type, authorization",
+	})
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204, got %d", rr.Code)
+	}
+	for header, want := range map[string]string{
+		"Access-Control-Allow-Origin":  portalOrigin,
+		"Access-Control-Allow-Methods": "POST",
+		"Access-Control-Allow-Headers": "Authorization",
+		"Access-Control-Max-Age":       "600",
+	} {
+		if got := rr.Header().Get(header); !strings.Contains(got, want) {
+			t.Errorf("Expected %s to contain %q, got %q", header, want, got)
+		}
+	}
+}
+
+func TestCORSAnyOrigin(t *testing.T) {
+	_, handler, _ := newTestServer(t, withCORS("*"))
+
+	origin := "http://localhost:3000"
+	rr := authRequest(handler, http.MethodGet, "/api/v1/stats", "", "", nil, map[string]string{"Origin": origin})
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != origin {
+		t.Errorf("Expected Access-Control-Allow-Origin %q, got %q", origin, got)
+	}
+}
+
+func TestCORSOffByDefault(t *testing.T) {
+	_, handler, _ := newTestServer(t, withCORS())
+
+	rr := authRequest(handler, http.MethodOptions, "/api/v1/stats", "", "", nil, map[string]string{
+		"Origin":                        portalOrigin,
+		"Access-Control-Request-Method": http.MethodGet,
+	})
+	if rr.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("Expected no CORS headers without allowed origins")
+	}
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected the preflight to reach the API and get 405, got %d", rr.Code)
+	}
+}
+
+func TestEmbed(t *testing.T) {
+	_, handler, _ := newTestServer(t, withCORS(portalOrigin))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/embed?theme=dark&accent=ff00aa&refresh=500&title=<Арена>", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/html") {
+		t.Errorf("Expected an HTML page, got %q", got)
+	}
+	if got := rr.Header().Get("Content-Security-Policy"); got != "frame-ancestors 'self' "+portalOrigin {
+		t.Errorf("Expected the portal to be allowed to frame the page, got %q", got)
+	}
+
+	body := rr.Body.String()
+	for _, want := range []string{"--bg: #1e1e1e", "--accent: #ff00aa", `data-refresh="500"`, "&lt;Арена&gt;"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page to contain %q", want)
+		}
+	}
+	if strings.Contains(body, "startJuggling") || strings.Contains(body, "<Арена>") {
+		t.Error("Expected a page without controls and with the title escaped")
+	}
+}
+
+func TestEmbedInvalidParameters(t *testing.T) {
+	_, handler, _ := newTestServer(t, withCORS())
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/embed?theme=neon&bg=red&refresh=10", nil))
+	if rr.Code != http.StatusBadRequest || errorCode(rr) != web.CodeBadRequest {
+		t.Fatalf("Expected 400 bad_request, got %d %s", rr.Code, errorCode(rr))
+	}
+	for _, field := range []string{"theme", "bg", "refresh"} {
+		if !strings.Contains(rr.Body.String(), `"field":"`+field+`"`) {
+			t.Errorf("Expected %s to be reported invalid: %s", field, rr.Body.String())
+		}
+	}
+}
+
+func TestEmbedPublicWithAuth(t *testing.T) {
+	_, handler, _ := newTestServer(t, withAuth())
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/embed", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected the embed page to load without logging in, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Content-Security-Policy"); got != "frame-ancestors 'self'" {
+		t.Errorf("Expected only the server itself to frame the page, got %q", got)
+	}
+}
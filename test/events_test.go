@@ -0,0 +1,176 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+// withManualSession runs a manual session on a test server's juggler, in
+// which balls fly long enough not to land during a test
+func withManualSession() serverOption {
+	return func(t *testing.T, s *web.Server, j *juggler.Juggler) {
+		j.SetOptions(juggler.Options{MinFlightTime: 30, MaxFlightTime: 40, ThrowInterval: 100 * time.Millisecond,
+			Manual: true, HoldTime: time.Minute})
+		j.Reset(3, 1)
+		j.Start()
+	}
+}
+
+// openEventStream connects to the event stream, returning a reader of its lines
+func openEventStream(t *testing.T, url string, header map[string]string) *bufio.Reader {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req.Header.Set("Accept", "text/event-stream")
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %q", got)
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+// nextEvent reads the next event from a stream, returning its ID and message
+func nextEvent(t *testing.T, r *bufio.Reader) (string, web.EventMessage) {
+	t.Helper()
+	var id string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Reading the stream: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if v, ok := strings.CutPrefix(line, "id: "); ok {
+			id = v
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			var msg web.EventMessage
+			if err := json.Unmarshal([]byte(data), &msg); err != nil {
+				t.Fatalf("Decoding %s: %v", data, err)
+			}
+			return id, msg
+		}
+	}
+}
+
+func TestEventsPage(t *testing.T) {
+	_, handler, j := newTestServer(t, withManualSession())
+	if _, err := j.Throw(0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	rr := getWith(handler, "/api/v1/events", nil)
+	var resp web.EventsResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Decoding %s: %v", rr.Body.String(), err)
+	}
+	if len(resp.Events) != 1 || resp.Events[0].Type != "throw" || resp.Next != 1 {
+		t.Errorf("Expected the throw and next 1, got %+v", resp)
+	}
+	if resp.Session != j.GetSession() {
+		t.Errorf("Expected session %d, got %d", j.GetSession(), resp.Session)
+	}
+
+	rr = getWith(handler, "/api/v1/events?since=1", nil)
+	if !strings.Contains(rr.Body.String(), `"events":[]`) {
+		t.Errorf("Expected no events after the throw, got %s", rr.Body.String())
+	}
+
+	rr = getWith(handler, "/api/v1/events?since=-1", nil)
+	if rr.Code != http.StatusBadRequest || errorCode(rr) != web.CodeBadRequest {
+		t.Errorf("Expected 400 bad_request for a negative since, got %d %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestEventsStream(t *testing.T) {
+	_, handler, j := newTestServer(t, withManualSession())
+	if _, err := j.Throw(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	// Closed after the streams, which are cancelled by their own cleanups
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	stream := openEventStream(t, server.URL+"/api/v1/events", nil)
+	before := time.Now()
+	ball, err := j.Throw(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The throw made before connecting is not sent
+	id, msg := nextEvent(t, stream)
+	if msg.Type != "throw" || msg.BallID != ball.ID || msg.Index != 1 {
+		t.Errorf("Expected the second throw of ball %d, got %+v", ball.ID, msg)
+	}
+	if want := fmt.Sprintf("%d:1", j.GetSession()); id != want || msg.Session != j.GetSession() {
+		t.Errorf("Expected ID %s, got %s", want, id)
+	}
+	if msg.Time.Before(before.Add(-time.Second)) || msg.Time.After(time.Now()) {
+		t.Errorf("Expected the engine's timestamp of the throw, got %v", msg.Time)
+	}
+	if elapsed := time.Since(before); elapsed > time.Second {
+		t.Errorf("Expected the event within the stream interval, took %v", elapsed)
+	}
+}
+
+func TestEventsStreamResumes(t *testing.T) {
+	_, handler, j := newTestServer(t, withManualSession())
+	for range 2 {
+		if _, err := j.Throw(0, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Closed after the streams, which are cancelled by their own cleanups
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	stream := openEventStream(t, server.URL+"/api/v1/events?since=0", nil)
+	if _, msg := nextEvent(t, stream); msg.Index != 0 {
+		t.Errorf("Expected the stream to start at since=0, got index %d", msg.Index)
+	}
+
+	header := map[string]string{"Last-Event-ID": fmt.Sprintf("%d:0", j.GetSession())}
+	stream = openEventStream(t, server.URL+"/api/v1/events", header)
+	if _, msg := nextEvent(t, stream); msg.Index != 1 {
+		t.Errorf("Expected the stream to resume after the last event, got index %d", msg.Index)
+	}
+}
+
+func TestHomeSoundControls(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	body := getWith(handler, "/", nil).Body.String()
+	for _, want := range []string{`id="sound-input"`, `id="metronome-input"`, "/static/sound.js?v="} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page to contain %s", want)
+		}
+	}
+
+	script := getWith(handler, "/static/sound.js", nil).Body.String()
+	if !strings.Contains(script, "new EventSource('/api/v1/events')") {
+		t.Error("Expected the sounds to follow the event stream")
+	}
+}
@@ -0,0 +1,277 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"juggler/internal/grpcapi"
+	"juggler/internal/grpcapi/jugglerpb"
+	"juggler/internal/i18n"
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+// newGRPCClient serves the gRPC API of a web server over an in-process
+// listener and returns a client connected to it
+func newGRPCClient(t *testing.T, s *web.Server, j *juggler.Juggler) *grpcapi.Client {
+	t.Helper()
+	return newGRPCClientFor(t, grpcapi.NewService(s, j))
+}
+
+// newGRPCClientFor serves a service over an in-process listener and
+// returns a client sending the given token, if any
+func newGRPCClientFor(t *testing.T, service *grpcapi.Service, token ...grpcapi.TokenCredentials) *grpcapi.Client {
+	t.Helper()
+	return grpcapi.NewClient(newGRPCConn(t, service, token...))
+}
+
+// newGRPCConn serves a service over an in-process listener and returns a
+// connection to it sending the given token, if any
+func newGRPCConn(t *testing.T, service *grpcapi.Service, token ...grpcapi.TokenCredentials) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpcapi.NewServer(service)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	opts := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	for _, tok := range token {
+		opts = append(opts, grpc.WithPerRPCCredentials(tok))
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestGRPCStartStopStats(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	defer j.Stop()
+	c := newGRPCClient(t, web.NewServer(j, 8080), j)
+	ctx := context.Background()
+
+	started, err := c.Start(ctx, web.StartRequest{TotalBalls: 3, TimeMinutes: 1})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if started.Status != "started" || started.Session == nil || started.Session.TotalBalls != 3 {
+		t.Errorf("Expected a started session with 3 balls, got %+v", started)
+	}
+
+	_, err = c.Start(ctx, web.StartRequest{TotalBalls: 2, TimeMinutes: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition while running, got %v", err)
+	}
+
+	stats, err := c.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	if !stats.IsRunning || stats.TotalBalls != 3 || len(stats.Balls) != 3 {
+		t.Errorf("Expected a running session with 3 balls, got %+v", stats)
+	}
+
+	stopped, err := c.Stop(ctx)
+	if err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if stopped.Status != "stopped" {
+		t.Errorf("Expected status stopped, got %q", stopped.Status)
+	}
+	if j.IsRunning() {
+		t.Error("Expected the juggler to stop")
+	}
+}
+
+func TestGRPCStartInvalid(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	c := newGRPCClient(t, web.NewServer(j, 8080), j)
+
+	_, err := c.Start(context.Background(), web.StartRequest{TotalBalls: 0, TimeMinutes: 1})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+	if len(fields) != 1 || fields[0] != "total_balls" {
+		t.Errorf("Expected a violation of total_balls, got %v", fields)
+	}
+}
+
+func TestGRPCLocalizedErrors(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	c := newGRPCClient(t, web.NewServer(j, 8080), j)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "ru-RU,ru;q=0.9")
+	_, err := c.Start(ctx, web.StartRequest{TotalBalls: 0, TimeMinutes: 1})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", err)
+	}
+	if want := i18n.T(i18n.Russian, "error.invalid_start"); st.Message() != want {
+		t.Errorf("Expected message %q, got %q", want, st.Message())
+	}
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				if want := i18n.T(i18n.Russian, "field.positive"); v.Description != want {
+					t.Errorf("Expected %s to be %q, got %q", v.Field, want, v.Description)
+				}
+			}
+		}
+	}
+
+	resp, err := c.Stop(ctx)
+	if err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if want := i18n.T(i18n.Russian, "status.stopped"); resp.Message != want {
+		t.Errorf("Expected message %q, got %q", want, resp.Message)
+	}
+}
+
+func TestGRPCWatchEvents(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetOptions(juggler.Options{MinFlightTime: 1, MaxFlightTime: 1, ThrowInterval: 50 * time.Millisecond})
+	defer j.Stop()
+	s := web.NewServer(j, 8080)
+	c := newGRPCClient(t, s, j)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	first, err := c.Start(ctx, web.StartRequest{TotalBalls: 2, TimeMinutes: 1})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	stream, err := c.WatchEvents(ctx, grpcapi.WatchRequest{})
+	if err != nil {
+		t.Fatalf("WatchEvents: %v", err)
+	}
+
+	// Throws come first, then the balls land a second later
+	seen := make(map[string]bool)
+	for !seen["catch"] {
+		e, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if e.Session != first.Session.ID {
+			t.Fatalf("Expected events of session %d, got session %d", first.Session.ID, e.Session)
+		}
+		seen[e.Type] = true
+	}
+	if !seen["throw"] {
+		t.Error("Expected a throw before the first catch")
+	}
+
+	// The stream follows a restarted session
+	restarted, err := c.Start(ctx, web.StartRequest{TotalBalls: 1, TimeMinutes: 1, Mode: web.StartModeRestart})
+	if err != nil {
+		t.Fatalf("Restart: %v", err)
+	}
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if e.Session == restarted.Session.ID {
+			break
+		}
+	}
+}
+
+func TestGRPCGeneratedClient(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	defer j.Stop()
+	c := jugglerpb.NewJugglerClient(newGRPCConn(t, grpcapi.NewService(web.NewServer(j, 8080), j)))
+	ctx := context.Background()
+
+	started, err := c.Start(ctx, &jugglerpb.StartRequest{TotalBalls: 2, TimeMinutes: 1,
+		Balls: []*jugglerpb.BallDef{{Type: "club", Color: "#ff0000"}}})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if started.GetStatus() != "started" || started.GetSession().GetStartTime() == nil {
+		t.Errorf("Expected a started session with its start time, got %v", started)
+	}
+
+	stats, err := c.GetStats(ctx, &jugglerpb.StatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	if len(stats.GetBalls()) != 2 || stats.GetBalls()[0].GetProp().GetType() != "club" {
+		t.Errorf("Expected 2 balls, the first a club, got %v", stats.GetBalls())
+	}
+}
+
+func TestGRPCReflection(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	conn := newGRPCConn(t, grpcapi.NewService(web.NewServer(j, 8080), j))
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.CloseSend()
+
+	stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		found = found || svc.GetName() == grpcapi.ServiceName
+	}
+	if !found {
+		t.Errorf("Expected reflection to list %s, got %v", grpcapi.ServiceName, resp)
+	}
+
+	stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: grpcapi.ServiceName},
+	})
+	resp, err = stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetFileDescriptorResponse().GetFileDescriptorProto()) == 0 {
+		t.Errorf("Expected reflection to describe %s, got %v", grpcapi.ServiceName, resp)
+	}
+}
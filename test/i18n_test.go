@@ -0,0 +1,193 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"juggler/internal/config"
+	"juggler/internal/i18n"
+	"juggler/internal/passing"
+	"juggler/internal/web"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	langs := i18n.Languages()
+	if !slices.Contains(langs, i18n.Russian) || !slices.Contains(langs, i18n.English) {
+		t.Fatalf("Expected Russian and English catalogs, got %v", langs)
+	}
+
+	want := i18n.Keys(langs[0])
+	for _, lang := range langs[1:] {
+		got := i18n.Keys(lang)
+		for _, key := range want {
+			if !slices.Contains(got, key) {
+				t.Errorf("Catalog %s lacks %s from %s", lang, key, langs[0])
+			}
+		}
+		for _, key := range got {
+			if !slices.Contains(want, key) {
+				t.Errorf("Catalog %s has %s missing from %s", lang, key, langs[0])
+			}
+		}
+	}
+	for _, lang := range langs {
+		for _, key := range i18n.Keys(lang) {
+			if i18n.T(lang, key) == "" {
+				t.Errorf("Catalog %s has an empty %s", lang, key)
+			}
+		}
+	}
+}
+
+func TestMatchLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"ru", "ru"},
+		{"en-US,en;q=0.9", "en"},
+		{"de-DE,ru;q=0.5,en;q=0.8", "en"},
+		{"fr, de", ""},
+		{"en;q=0, ru;q=0.1", "ru"},
+		{"*", i18n.Local()},
+	}
+	for _, tt := range tests {
+		if got := i18n.Match(tt.header); got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if got := i18n.T(i18n.English, "error.ball_not_found", 7); got != "Ball 7 not found" {
+		t.Errorf("Expected the English message, got %q", got)
+	}
+	if got := i18n.T("xx", "config.summary", 80); got != i18n.T(i18n.Local(), "config.summary", 80) {
+		t.Errorf("Expected an unsupported language to fall back to the local one, got %q", got)
+	}
+	if got := i18n.T(i18n.English, "no.such.key"); got != "no.such.key" {
+		t.Errorf("Expected an unknown key as it is, got %q", got)
+	}
+	if err := i18n.SetLocal("xx"); err == nil {
+		t.Error("Expected an error for an unsupported local language")
+	}
+}
+
+func TestLocalizedAPIErrors(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		cookies []*http.Cookie
+		header  map[string]string
+		want    string
+	}{
+		{"default", "/api/v1/missing", nil, nil, "Not found"},
+		{"accept-language", "/api/v1/missing", nil, map[string]string{"Accept-Language": "ru-RU,ru;q=0.9"}, "Не найдено"},
+		{"query", "/api/v1/missing?lang=ru", nil, map[string]string{"Accept-Language": "en"}, "Не найдено"},
+		{"cookie", "/api/v1/missing", []*http.Cookie{{Name: web.LanguageCookie, Value: "ru"}}, nil, "Не найдено"},
+		{"unsupported", "/api/v1/missing?lang=xx", nil, nil, "Not found"},
+	}
+	for _, tt := range tests {
+		rr := authRequest(handler, http.MethodGet, tt.path, "", "", tt.cookies, tt.header)
+		var resp web.ErrorResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: decoding %s: %v", tt.name, rr.Body.String(), err)
+		}
+		if resp.Error.Code != web.CodeNotFound {
+			t.Errorf("%s: expected code %s, got %s", tt.name, web.CodeNotFound, resp.Error.Code)
+		}
+		if resp.Error.Message != tt.want {
+			t.Errorf("%s: expected message %q, got %q", tt.name, tt.want, resp.Error.Message)
+		}
+	}
+}
+
+func TestLocalizedFieldErrors(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	_, parseErr := passing.Parse("p x | s s")
+	if parseErr == nil {
+		t.Fatal("Expected the pattern to be invalid")
+	}
+	body := `{"total_balls":0,"time_minutes":1,"passing":"p x | s s"}`
+	rr := authRequest(handler, http.MethodPost, "/api/v1/start?lang=ru", "", body, nil, nil)
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status 422, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp web.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Decoding %s: %v", rr.Body.String(), err)
+	}
+
+	want := map[string]string{
+		"total_balls": i18n.T(i18n.Russian, "field.positive"),
+		"passing":     i18n.Message(i18n.Russian, parseErr),
+	}
+	if want["passing"] == parseErr.Error() {
+		t.Fatalf("Expected the pattern error to have a Russian message, got %q", want["passing"])
+	}
+	got := map[string]string{}
+	for _, d := range resp.Error.Details {
+		got[d.Field] = d.Message
+	}
+	for field, msg := range want {
+		if got[field] != msg {
+			t.Errorf("Expected %s to be %q, got %q", field, msg, got[field])
+		}
+	}
+}
+
+func TestLocalizedPage(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	for lang, want := range map[string]string{
+		i18n.Russian: i18n.T(i18n.Russian, "page.start"),
+		i18n.English: i18n.T(i18n.English, "page.start"),
+	} {
+		rr := getWith(handler, "/?lang="+lang, nil)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rr.Code)
+		}
+		if got := rr.Header().Get("Content-Language"); got != lang {
+			t.Errorf("Expected Content-Language %s, got %q", lang, got)
+		}
+		body := rr.Body.String()
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page in %s to contain %q", lang, want)
+		}
+		if !strings.Contains(body, `id="lang-select"`) || !strings.Contains(body, `value="`+lang+`" selected`) {
+			t.Errorf("Expected a language switcher with %s selected", lang)
+		}
+	}
+
+	rr := getWith(handler, "/", map[string]string{"Accept-Language": "ru"})
+	if got := rr.Header().Get("Content-Language"); got != i18n.Russian {
+		t.Errorf("Expected the page in Russian by Accept-Language, got %q", got)
+	}
+	if !strings.Contains(rr.Header().Get("Vary"), "Accept-Language") {
+		t.Error("Expected Vary: Accept-Language")
+	}
+}
+
+func TestConfigLanguage(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Lang = i18n.English
+	cfg.WebPort = 9000
+	if got := cfg.String(); got != "Port: 9000" {
+		t.Errorf("Expected the summary in English, got %q", got)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected English to be valid, got %v", err)
+	}
+
+	cfg.Lang = "xx"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected an error for an unsupported language")
+	}
+}
@@ -2,6 +2,8 @@ package test
 
 import (
 	"context"
+	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -340,3 +342,219 @@ func TestJugglerBallFlightAndCatch(t *testing.T) {
 		}
 	}
 }
+
+func TestJugglerPauseResume(t *testing.T) {
+	j := juggler.NewJuggler(3, 2)
+	j.Reset(3, 2)
+
+	j.Pause()
+	if !j.IsPaused() {
+		t.Fatal("Expected juggler to be paused")
+	}
+
+	if !j.IsRunning() {
+		t.Error("Expected paused juggler to still be running")
+	}
+
+	elapsed := j.GetElapsed()
+	time.Sleep(50 * time.Millisecond)
+	if j.GetElapsed() != elapsed {
+		t.Errorf("Expected elapsed time to stand still while paused, got %v then %v", elapsed, j.GetElapsed())
+	}
+
+	j.Resume()
+	if j.IsPaused() {
+		t.Error("Expected juggler to not be paused after resume")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if j.GetElapsed() <= elapsed {
+		t.Error("Expected elapsed time to advance after resume")
+	}
+
+	j.Pause()
+	j.Stop()
+	if j.IsPaused() {
+		t.Error("Expected stop to clear the pause")
+	}
+}
+
+func TestJugglerAddBall(t *testing.T) {
+	j := juggler.NewJuggler(2, 2)
+	j.Reset(2, 2)
+
+	id := j.AddBall()
+	if id != 3 {
+		t.Errorf("Expected new ball to get ID 3, got %d", id)
+	}
+
+	if j.GetTotalBalls() != 3 {
+		t.Errorf("Expected 3 total balls, got %d", j.GetTotalBalls())
+	}
+
+	inHand, inAir, _ := j.GetStats()
+	if inHand != 3 || inAir != 0 {
+		t.Errorf("Expected 3 in hand and 0 in air, got %d in hand, %d in air", inHand, inAir)
+	}
+}
+
+func TestJugglerHands(t *testing.T) {
+	j := juggler.NewJuggler(2, 2)
+	j.Reset(2, 2)
+	j.SetOutput(io.Discard)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eg := &errgroup.Group{}
+
+	j.ThrowBall(ctx, eg)
+	j.ThrowBall(ctx, eg)
+
+	events := j.GetEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 throw events, got %d", len(events))
+	}
+
+	if events[0].Hand != "right" || events[1].Hand != "left" {
+		t.Errorf("Expected balls to be thrown from right then left hand, got %s and %s", events[0].Hand, events[1].Hand)
+	}
+
+	_, _, balls := j.GetStats()
+	for _, ball := range balls {
+		expected := "left"
+		if ball.ID == 2 {
+			expected = "right"
+		}
+		if ball.Hand != expected {
+			t.Errorf("Expected ball %d to fly towards the %s hand, got %s", ball.ID, expected, ball.Hand)
+		}
+	}
+
+	cancel()
+	eg.Wait()
+}
+
+func TestJugglerResetEndsPreviousSession(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.Reset(3, 1)
+	j.Start()
+	first := j.GetSession()
+	previous := j.Done()
+
+	time.Sleep(700 * time.Millisecond)
+	j.Reset(2, 1)
+	defer j.Stop()
+
+	if j.GetSession() == first {
+		t.Error("Expected Reset to begin a new session")
+	}
+
+	select {
+	case <-previous:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected the previous session's goroutines to exit after Reset")
+	}
+
+	inHand, inAir, _ := j.GetStats()
+	if inHand != 2 || inAir != 0 {
+		t.Errorf("Expected the new session untouched with 2 balls in hand, got %d in hand, %d in air", inHand, inAir)
+	}
+}
+
+func TestJugglerRemoveBall(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetOptions(juggler.Options{MinFlightTime: 1, MaxFlightTime: 1, ThrowInterval: time.Second})
+	j.Reset(3, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eg := &errgroup.Group{}
+	j.ThrowBall(ctx, eg) // ball 1 is in flight
+
+	if removed, err := j.RemoveBall(2); err != nil || !removed {
+		t.Errorf("Expected ball 2 in hand to be removed at once, got %v, %v", removed, err)
+	}
+	if removed, err := j.RemoveBall(1); err != nil || removed {
+		t.Errorf("Expected ball 1 in flight to be removed when it lands, got %v, %v", removed, err)
+	}
+	if _, err := j.RemoveBall(42); !errors.Is(err, juggler.ErrUnknownBall) {
+		t.Errorf("Expected ErrUnknownBall for an unknown ball, got %v", err)
+	}
+	if j.GetTotalBalls() != 2 {
+		t.Errorf("Expected 2 balls while ball 1 is still in flight, got %d", j.GetTotalBalls())
+	}
+
+	eg.Wait()
+
+	inHand, inAir, balls := j.GetStats()
+	if j.GetTotalBalls() != 1 || inHand != 1 || inAir != 0 {
+		t.Errorf("Expected only ball 3 left in hand, got %d balls, %d in hand, %d in air", j.GetTotalBalls(), inHand, inAir)
+	}
+	if len(balls) != 1 || balls[0].ID != 3 {
+		t.Errorf("Expected ball 3 to keep its ID, got %+v", balls)
+	}
+}
+
+func TestJugglerManualThrow(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.Reset(3, 1)
+	if _, err := j.Throw(0, 0); !errors.Is(err, juggler.ErrNotManual) {
+		t.Errorf("Expected ErrNotManual outside manual mode, got %v", err)
+	}
+
+	j.SetOptions(juggler.Options{MinFlightTime: 1, MaxFlightTime: 2, ThrowInterval: 100 * time.Millisecond,
+		Manual: true, HoldTime: time.Minute, HandCapacity: 1})
+	j.Reset(3, 1)
+	if _, err := j.Throw(0, 0); !errors.Is(err, juggler.ErrNotRunning) {
+		t.Errorf("Expected ErrNotRunning before Start, got %v", err)
+	}
+
+	j.Start()
+	defer j.Stop()
+	time.Sleep(300 * time.Millisecond)
+
+	if events := j.GetEvents(); len(events) != 0 {
+		t.Errorf("Expected the engine not to throw in manual mode, got %d events", len(events))
+	}
+	if _, err := j.Throw(2, 5); !errors.Is(err, juggler.ErrHeight) {
+		t.Errorf("Expected ErrHeight for a height outside the flight range, got %v", err)
+	}
+
+	// Ball 2 leaves the left hand for the right one, which already holds
+	// balls 1 and 3 and can hold only one
+	ball, err := j.Throw(2, 1)
+	if err != nil {
+		t.Fatalf("Throw: %v", err)
+	}
+	if ball.ID != 2 || ball.Hand != "left" || ball.FlightTime != 1 {
+		t.Errorf("Expected ball 2 thrown from the left hand for 1 second, got %+v", ball)
+	}
+	if _, err := j.Throw(2, 1); !errors.Is(err, juggler.ErrNotInHand) {
+		t.Errorf("Expected ErrNotInHand for a ball in flight, got %v", err)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+
+	events := j.GetEvents()
+	if last := events[len(events)-1]; last.BallID != 2 || last.Type != "drop" {
+		t.Errorf("Expected ball 2 to be dropped into the full hand, got %+v", last)
+	}
+}
+
+func TestSimulateManualDropsHeldBalls(t *testing.T) {
+	j, _ := simulate(t, juggler.Options{MinFlightTime: 5, MaxFlightTime: 10, ThrowInterval: 500 * time.Millisecond,
+		Manual: true, HoldTime: 2 * time.Second}, 3, 1)
+
+	summary := juggler.Summarize(j.GetEvents())
+	if summary.Throws != 0 || summary.Drops != 3 {
+		t.Errorf("Expected no throws and 3 drops, got %d throws and %d drops", summary.Throws, summary.Drops)
+	}
+
+	events := j.GetEvents()
+	if held := events[0].Time.Sub(time.Date(2025, 7, 8, 12, 0, 0, 0, time.UTC)); held != 2*time.Second {
+		t.Errorf("Expected balls to be dropped after the 2 second hold time, got %v", held)
+	}
+}
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/juggler"
+	"juggler/internal/leaderboard"
+	"juggler/internal/web"
+)
+
+func TestLeaderboardPlayers(t *testing.T) {
+	st := leaderboard.NewStore()
+
+	if _, err := st.AddPlayer("Alice"); err != nil {
+		t.Fatalf("Expected Alice to be added, got %v", err)
+	}
+	if _, err := st.AddPlayer("alice"); !errors.Is(err, leaderboard.ErrPlayerExists) {
+		t.Errorf("Expected names to be unique regardless of case, got %v", err)
+	}
+	for _, name := range []string{"", "   ", strings.Repeat("x", 33)} {
+		if _, err := st.AddPlayer(name); !errors.Is(err, leaderboard.ErrInvalidName) {
+			t.Errorf("Expected %q to be rejected, got %v", name, err)
+		}
+	}
+
+	if p, ok := st.Player("ALICE"); !ok || p.Name != "Alice" {
+		t.Errorf("Expected to find Alice ignoring case, got %+v", p)
+	}
+	if err := st.Record(leaderboard.Run{Player: "Bob"}); !errors.Is(err, leaderboard.ErrUnknownPlayer) {
+		t.Errorf("Expected a run of an unknown player to be rejected, got %v", err)
+	}
+}
+
+func TestLeaderboardTop(t *testing.T) {
+	st := leaderboard.NewStore()
+	st.AddPlayer("Alice")
+	st.AddPlayer("Bob")
+
+	runs := []leaderboard.Run{
+		{Player: "alice", Session: 1, Balls: 3, Points: 100, Catches: 10},
+		{Player: "bob", Session: 2, Balls: 5, Points: 300, Catches: 5},
+		{Player: "alice", Session: 3, Balls: 3, Points: 200, Catches: 10},
+	}
+	for _, run := range runs {
+		if err := st.Record(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	top, err := st.Top("points", 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].Session != 2 || top[1].Session != 3 {
+		t.Errorf("Expected sessions 2 and 3 by points, got %+v", top)
+	}
+	if top[1].Player != "Alice" {
+		t.Errorf("Expected the player name to be canonical, got %q", top[1].Player)
+	}
+
+	top, _ = st.Top("catches", 3, 0)
+	if len(top) != 2 || top[0].Session != 1 || top[1].Session != 3 {
+		t.Errorf("Expected ties to keep the earlier run first, got %+v", top)
+	}
+
+	if _, err := st.Top("style", 0, 0); !errors.Is(err, leaderboard.ErrUnknownMetric) {
+		t.Errorf("Expected an unknown metric to be rejected, got %v", err)
+	}
+}
+
+func TestLeaderboardFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+
+	st, err := leaderboard.Open(path)
+	if err != nil {
+		t.Fatalf("Expected a missing file to open as an empty store, got %v", err)
+	}
+	st.AddPlayer("Alice")
+	st.Record(leaderboard.Run{Player: "Alice", Session: 1, Balls: 3, Points: 42})
+
+	reopened, err := leaderboard.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reopened.Players()) != 1 {
+		t.Errorf("Expected 1 saved player, got %d", len(reopened.Players()))
+	}
+	if top, _ := reopened.Top("points", 0, 0); len(top) != 1 || top[0].Points != 42 {
+		t.Errorf("Expected the saved run, got %+v", top)
+	}
+}
+
+func TestWebServerLeaderboard(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := send("POST", "/api/v1/players", `{"name": "Alice"}`); rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body)
+	}
+	if rr := send("POST", "/api/v1/players", `{"name": "alice"}`); rr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d for a duplicate, got %d", http.StatusConflict, rr.Code)
+	}
+	if rr := send("POST", "/api/v1/players", `{"name": ""}`); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d for an empty name, got %d", http.StatusUnprocessableEntity, rr.Code)
+	}
+	if rr := send("POST", "/api/v1/start", `{"total_balls": 3, "time_minutes": 1, "player": "Bob"}`); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d for an unknown player, got %d", http.StatusUnprocessableEntity, rr.Code)
+	}
+	if rr := send("GET", "/api/v1/leaderboard?metric=style", ""); rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for an unknown metric, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	rr := send("POST", "/api/v1/start", `{"total_balls": 3, "time_minutes": 1, "player": "alice"}`)
+	var started web.StartResponse
+	json.Unmarshal(rr.Body.Bytes(), &started)
+	if rr.Code != http.StatusOK || started.Session == nil || started.Session.Player != "Alice" {
+		t.Fatalf("Expected the session to be attributed to Alice, got %d %s", rr.Code, rr.Body)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for len(j.GetEvents()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// Restarting ends Alice's session, which records her run
+	send("POST", "/api/v1/start", `{"total_balls": 2, "time_minutes": 1, "mode": "restart"}`)
+
+	rr = send("GET", "/api/v1/leaderboard?balls=3", "")
+	var board web.LeaderboardResponse
+	json.Unmarshal(rr.Body.Bytes(), &board)
+	if rr.Code != http.StatusOK || board.Metric != "points" || board.Balls != 3 {
+		t.Fatalf("Expected the points leaderboard for 3 balls, got %d %s", rr.Code, rr.Body)
+	}
+	if len(board.Entries) != 1 || board.Entries[0].Rank != 1 || board.Entries[0].Player != "Alice" ||
+		board.Entries[0].Session != started.Session.ID {
+		t.Errorf("Expected Alice's run to be ranked first, got %+v", board.Entries)
+	}
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/config"
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+func TestRateLimit(t *testing.T) {
+	handler := web.RateLimit(1, 3, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	get := func(addr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/stats", nil)
+		req.RemoteAddr = addr
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for i := 0; i < 3; i++ {
+		if rr := get("192.0.2.1:1000"); rr.Code != http.StatusNoContent {
+			t.Fatalf("Expected request %d of the burst to pass, got %d", i+1, rr.Code)
+		}
+	}
+
+	rr := get("192.0.2.1:2000")
+	if rr.Code != http.StatusTooManyRequests || errorCode(rr) != web.CodeRateLimited {
+		t.Fatalf("Expected 429 rate_limited once the burst is spent, got %d %s", rr.Code, rr.Body.String())
+	}
+	if after, err := strconv.Atoi(rr.Header().Get("Retry-After")); err != nil || after < 1 {
+		t.Errorf("Expected Retry-After in whole seconds, got %q", rr.Header().Get("Retry-After"))
+	}
+
+	if rr := get("198.51.100.7:1000"); rr.Code != http.StatusNoContent {
+		t.Errorf("Expected another client to have its own bucket, got %d", rr.Code)
+	}
+}
+
+func TestRateLimitRefills(t *testing.T) {
+	handler := web.RateLimit(20, 1, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	get := func() int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+		return rr.Code
+	}
+
+	if get() != http.StatusOK || get() != http.StatusTooManyRequests {
+		t.Fatal("Expected a burst of one request")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if code := get(); code != http.StatusOK {
+		t.Errorf("Expected the bucket to refill, got %d", code)
+	}
+}
+
+func TestWebServerLimits(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	defer j.Stop()
+
+	s := web.NewServer(j, 8080)
+	limits := web.DefaultLimits()
+	limits.MaxBodyBytes = 64
+	limits.RequestsPerSecond, limits.Burst = 1, 2
+	s.SetLimits(limits)
+	handler := s.Handler()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/start", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	big := `{"total_balls": 3, "time_minutes": 1, "player": "` + strings.Repeat("x", 100) + `"}`
+	rr := post(big)
+	if rr.Code != http.StatusRequestEntityTooLarge || errorCode(rr) != web.CodeBodyTooLarge {
+		t.Errorf("Expected 413 body_too_large, got %d %s", rr.Code, rr.Body.String())
+	}
+	if j.IsRunning() {
+		t.Error("Expected an oversized request not to start a session")
+	}
+
+	if rr := post(`{"total_balls": 3, "time_minutes": 1}`); rr.Code != http.StatusOK {
+		t.Errorf("Expected a small request to pass, got %d %s", rr.Code, rr.Body.String())
+	}
+	if rr := post(`{"total_balls": 3, "time_minutes": 1, "mode": "restart"}`); rr.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected the third request to be rate limited, got %d", rr.Code)
+	}
+}
+
+func TestWebServerLimitsOnlyAPI(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	defer j.Stop()
+
+	s := web.NewServer(j, 8080)
+	limits := web.DefaultLimits()
+	limits.RequestsPerSecond, limits.Burst = 1, 1
+	s.SetLimits(limits)
+	handler := s.Handler()
+
+	get := func(path string) int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		return rr.Code
+	}
+
+	for i := 0; i < 5; i++ {
+		for _, path := range []string{"/", "/embed", "/static/home.js"} {
+			if code := get(path); code != http.StatusOK {
+				t.Fatalf("Expected %s to be served without a rate limit, got %d", path, code)
+			}
+		}
+	}
+
+	if code := get("/api/v1/stats"); code != http.StatusOK {
+		t.Fatalf("Expected the first API request to pass, got %d", code)
+	}
+	if code := get("/api/stats"); code != http.StatusTooManyRequests {
+		t.Errorf("Expected the legacy API to share the budget, got %d", code)
+	}
+}
+
+func TestDefaultLimitsMatchConfig(t *testing.T) {
+	limits, cfg := web.DefaultLimits(), config.DefaultConfig()
+	if limits.RequestsPerSecond != cfg.RateLimit || limits.Burst != cfg.RateBurst || limits.MaxBodyBytes != cfg.MaxBodyBytes {
+		t.Errorf("Expected the server defaults %+v to match the config defaults %+v", limits, cfg)
+	}
+}
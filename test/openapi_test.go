@@ -0,0 +1,222 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"juggler/client"
+	"juggler/internal/batch"
+	"juggler/internal/juggler"
+	"juggler/internal/leaderboard"
+	"juggler/internal/peer"
+	"juggler/internal/score"
+	"juggler/internal/web"
+)
+
+type openAPIDoc struct {
+	OpenAPI string                                `json:"openapi"`
+	Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	Comps   struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func loadOpenAPI(t *testing.T) openAPIDoc {
+	t.Helper()
+
+	j := juggler.NewJuggler(0, 0)
+	server := web.NewServer(j, 8080)
+
+	req := httptest.NewRequest("GET", "/api/v1/openapi.json", nil)
+	rr := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var doc openAPIDoc
+	if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse OpenAPI document: %v", err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("Expected OpenAPI 3 document, got version %q", doc.OpenAPI)
+	}
+
+	return doc
+}
+
+func TestOpenAPIDocumentsAllRoutes(t *testing.T) {
+	doc := loadOpenAPI(t)
+	server := web.NewServer(juggler.NewJuggler(0, 0), 8080)
+
+	served := make(map[string]bool)
+	for _, route := range server.Routes() {
+		key := route.Method + " " + route.Path
+		served[key] = true
+
+		if _, ok := doc.Paths[route.Path][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("Route %s is not documented in openapi.json", key)
+		}
+	}
+
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			if key := strings.ToUpper(method) + " " + path; !served[key] {
+				t.Errorf("Documented operation %s has no route", key)
+			}
+		}
+	}
+}
+
+func TestOpenAPIMethodsMatchHandlers(t *testing.T) {
+	doc := loadOpenAPI(t)
+	server := web.NewServer(juggler.NewJuggler(0, 0), 8080)
+	limits := web.DefaultLimits()
+	limits.RequestsPerSecond = 0 // every documented method of every path is tried at once
+	server.SetLimits(limits)
+	handler := server.Handler()
+
+	for path, ops := range doc.Paths {
+		url := strings.NewReplacer("/balls/{id}", "/balls/1", "{id}", "exp-0", "{chart}", "drop_rate.svg").Replace(path)
+
+		for _, method := range []string{"GET", "POST", "DELETE"} {
+			_, documented := ops[strings.ToLower(method)]
+
+			req := httptest.NewRequest(method, url, strings.NewReader("{}"))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if documented && rr.Code == http.StatusMethodNotAllowed {
+				t.Errorf("%s %s is documented but the handler rejects the method", method, path)
+			}
+			if !documented && rr.Code != http.StatusMethodNotAllowed {
+				t.Errorf("%s %s is not documented but the handler returned %d", method, path, rr.Code)
+			}
+		}
+	}
+}
+
+// jsonFields returns the sorted JSON property names of a struct type, including embedded structs
+func jsonFields(typ reflect.Type) []string {
+	var fields []string
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.Anonymous {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func TestOpenAPISchemasMatchTypes(t *testing.T) {
+	doc := loadOpenAPI(t)
+
+	types := []struct {
+		schema string
+		value  interface{}
+	}{
+		{"Ball", juggler.Ball{}},
+		{"BallDef", juggler.BallDef{}},
+		{"PerformerOptions", juggler.PerformerOptions{}},
+		{"PerformerState", juggler.PerformerState{}},
+		{"TroupeMember", juggler.TroupeMember{}},
+		{"PeerStatus", peer.Status{}},
+		{"StatsResponse", web.StatsResponse{}},
+		{"StartRequest", web.StartRequest{}},
+		{"StatusResponse", web.StatusResponse{}},
+		{"StartResponse", web.StartResponse{}},
+		{"SessionInfo", web.SessionInfo{}},
+		{"BallResponse", web.BallResponse{}},
+		{"ThrowRequest", web.ThrowRequest{}},
+		{"ThrowResponse", web.ThrowResponse{}},
+		{"Score", score.Score{}},
+		{"Achievement", score.Achievement{}},
+		{"Player", leaderboard.Player{}},
+		{"PlayerRequest", web.PlayerRequest{}},
+		{"LeaderboardEntry", web.LeaderboardEntry{}},
+		{"LeaderboardResponse", web.LeaderboardResponse{}},
+		{"ErrorResponse", web.ErrorResponse{}},
+		{"ErrorBody", web.ErrorBody{}},
+		{"FieldError", web.FieldError{}},
+		{"Event", juggler.Event{}},
+		{"ExportEvent", web.ExportEvent{}},
+		{"BallSummary", juggler.BallSummary{}},
+		{"ExportResponse", web.ExportResponse{}},
+		{"EventsResponse", web.EventsResponse{}},
+		{"EventMessage", web.EventMessage{}},
+		{"FlightRange", batch.FlightRange{}},
+		{"ExperimentRequest", web.ExperimentRequest{}},
+		{"ExperimentRow", batch.Row{}},
+		{"ExperimentResponse", web.ExperimentResponse{}},
+		{"EngineOptions", web.EngineOptions{}},
+		{"LoginRequest", web.LoginRequest{}},
+		{"AuthStatus", web.AuthStatus{}},
+		{"Ball", client.Ball{}},
+		{"BallDef", client.BallDef{}},
+		{"PerformerOptions", client.PerformerOptions{}},
+		{"PerformerState", client.PerformerState{}},
+		{"TroupeMember", client.TroupeMember{}},
+		{"PeerStatus", client.PeerStatus{}},
+		{"StatsResponse", client.StatsResponse{}},
+		{"StartRequest", client.StartRequest{}},
+		{"StatusResponse", client.StatusResponse{}},
+		{"StartResponse", client.StartResponse{}},
+		{"SessionInfo", client.SessionInfo{}},
+		{"BallResponse", client.BallResponse{}},
+		{"ThrowRequest", client.ThrowRequest{}},
+		{"ThrowResponse", client.ThrowResponse{}},
+		{"Score", client.Score{}},
+		{"Achievement", client.Achievement{}},
+		{"Player", client.Player{}},
+		{"LeaderboardEntry", client.LeaderboardEntry{}},
+		{"LeaderboardResponse", client.LeaderboardResponse{}},
+		{"FieldError", client.FieldError{}},
+		{"Event", client.Event{}},
+		{"ExportEvent", client.ExportEvent{}},
+		{"BallSummary", client.BallSummary{}},
+		{"ExportResponse", client.ExportResponse{}},
+		{"EventsResponse", client.EventsResponse{}},
+		{"EventMessage", client.EventMessage{}},
+		{"FlightRange", client.FlightRange{}},
+		{"ExperimentRequest", client.ExperimentRequest{}},
+		{"ExperimentRow", client.ExperimentRow{}},
+		{"ExperimentResponse", client.ExperimentResponse{}},
+		{"EngineOptions", client.EngineOptions{}},
+		{"AuthStatus", client.AuthStatus{}},
+	}
+
+	for _, tt := range types {
+		typ := reflect.TypeOf(tt.value)
+		t.Run(typ.String(), func(t *testing.T) {
+			schema, ok := doc.Comps.Schemas[tt.schema]
+			if !ok {
+				t.Fatalf("Schema %s is missing", tt.schema)
+			}
+
+			documented := make([]string, 0, len(schema.Properties))
+			for name := range schema.Properties {
+				documented = append(documented, name)
+			}
+			sort.Strings(documented)
+
+			if actual := jsonFields(typ); !reflect.DeepEqual(actual, documented) {
+				t.Errorf("Schema %s documents %v but %s has %v", tt.schema, documented, typ, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/juggler"
+	"juggler/internal/passing"
+	"juggler/internal/web"
+)
+
+func TestPassingParse(t *testing.T) {
+	tests := []struct {
+		notation   string
+		performers int
+		targets    [][]int
+	}{
+		{"p s | p s", 2, [][]int{{2, 1}, {1, 2}}},
+		{"P P|P P", 2, [][]int{{2, 2}, {1, 1}}},
+		{"p2 p3 | s p1 | p1 s", 3, [][]int{{2, 3}, {2, 1}, {1, 3}}},
+		{"p s s | s s p s s p", 2, [][]int{{2, 1, 1}, {2, 2, 1, 2, 2, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			p, err := passing.Parse(tt.notation)
+			if err != nil {
+				t.Fatalf("Expected %q to be valid, got %v", tt.notation, err)
+			}
+			if p.Performers() != tt.performers {
+				t.Errorf("Expected %d performers, got %d", tt.performers, p.Performers())
+			}
+			for i, targets := range tt.targets {
+				for throw, to := range targets {
+					if got := p.Target(i+1, throw); got != to {
+						t.Errorf("Expected throw %d of performer %d to go to %d, got %d", throw, i+1, to, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPassingParseErrors(t *testing.T) {
+	for _, notation := range []string{
+		"",
+		"p s",        // a single performer
+		"p s |",      // a performer without throws
+		"p x | p s",  // an unknown throw
+		"p1 s | p s", // a pass to the thrower
+		"p3 | p1",    // a pass to a performer who is not there
+		"p p | s s",  // performer 2 receives without passing back
+	} {
+		if _, err := passing.Parse(notation); err == nil {
+			t.Errorf("Expected %q to be rejected", notation)
+		}
+	}
+}
+
+func TestJugglerTroupe(t *testing.T) {
+	clock := juggler.NewVirtualClock(time.Date(2025, 7, 8, 12, 0, 0, 0, time.UTC))
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetClock(clock)
+	j.SetSeed(1)
+	opts := juggler.DefaultOptions()
+	opts.Passing = "p s | p s"
+	if err := j.SetOptions(opts); err != nil {
+		t.Fatal(err)
+	}
+	j.Reset(6, 1)
+
+	_, _, balls := j.GetStats()
+	for _, ball := range balls {
+		if expected := (ball.ID-1)%2 + 1; ball.Performer != expected {
+			t.Errorf("Expected ball %d to start with performer %d, got %d", ball.ID, expected, ball.Performer)
+		}
+	}
+
+	j.Simulate(clock)
+
+	members := j.GetTroupe()
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 performers, got %d", len(members))
+	}
+	passes := 0
+	for _, m := range members {
+		if m.PassesMade == 0 || m.Catches == 0 {
+			t.Errorf("Expected performer %d to pass and catch, got %+v", m.Performer, m)
+		}
+		if m.InHand != 3 {
+			t.Errorf("Expected performer %d to end with 3 balls in hand, got %d", m.Performer, m.InHand)
+		}
+		passes += m.PassesMade - m.PassesReceived
+	}
+	if passes != 0 {
+		t.Errorf("Expected every pass to be caught, %d were not", passes)
+	}
+
+	for _, e := range j.GetEvents() {
+		if e.Type == "throw" && e.To == e.Performer {
+			t.Fatalf("Expected self-throws not to be marked as passes, got %+v", e)
+		}
+	}
+
+	if juggler.NewJuggler(1, 1).GetTroupe() != nil {
+		t.Error("Expected a solo juggler to have no troupe")
+	}
+}
+
+func TestWebServerTroupe(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	start := func(body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/start", strings.NewReader(body)))
+		return rr
+	}
+
+	rr := start(`{"total_balls": 6, "time_minutes": 1, "passing": "p p | s s"}`)
+	var errResp web.ErrorResponse
+	json.Unmarshal(rr.Body.Bytes(), &errResp)
+	if rr.Code != http.StatusUnprocessableEntity || len(errResp.Error.Details) != 1 || errResp.Error.Details[0].Field != "passing" {
+		t.Fatalf("Expected an unbalanced pattern to be rejected, got %d %s", rr.Code, rr.Body)
+	}
+
+	if rr := start(`{"total_balls": 6, "time_minutes": 1, "passing": "p s | p s"}`); rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/stats", nil))
+	var stats web.StatsResponse
+	json.Unmarshal(rr.Body.Bytes(), &stats)
+	if stats.Passing != "p s | p s" || len(stats.Troupe) != 2 || stats.Troupe[0].InHand+stats.Troupe[1].InHand != 6 {
+		t.Errorf("Expected 2 performers holding 6 balls, got %q %+v", stats.Passing, stats.Troupe)
+	}
+}
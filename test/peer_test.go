@@ -0,0 +1,221 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"juggler/internal/juggler"
+	"juggler/internal/peer"
+)
+
+// newPeerJuggler starts a session whose balls fly for flight seconds and
+// whose every passEvery-th throw goes to the peer
+func newPeerJuggler(t *testing.T, balls, flight, passEvery int) *juggler.Juggler {
+	t.Helper()
+
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetOptions(juggler.Options{MinFlightTime: flight, MaxFlightTime: flight, ThrowInterval: 200 * time.Millisecond, PassEvery: passEvery})
+	j.Reset(balls, 1)
+	t.Cleanup(j.Stop)
+	return j
+}
+
+// connectPeers links two nodes over loopback and returns them
+func connectPeers(t *testing.T, a, b *juggler.Juggler) (*peer.Node, *peer.Node) {
+	t.Helper()
+
+	nodeA, nodeB := peer.NewNode(a, "a"), peer.NewNode(b, "b")
+	l, err := nodeA.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	if err := nodeB.Dial(l.Addr().String()); err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(nodeA.Close)
+	t.Cleanup(nodeB.Close)
+
+	waitFor(t, "the handshake", func() bool { return nodeA.Status().Connected })
+	return nodeA, nodeB
+}
+
+// waitFor polls cond for up to 5 seconds
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// remoteEvents counts the events of the given type involving the peer
+func remoteEvents(j *juggler.Juggler, typ string) int {
+	n := 0
+	for _, e := range j.GetEvents() {
+		if e.Remote && e.Type == typ {
+			n++
+		}
+	}
+	return n
+}
+
+func TestPeerPassesBalls(t *testing.T) {
+	a := newPeerJuggler(t, 2, 1, 1)
+	b := newPeerJuggler(t, 0, 1, 0)
+	nodeA, nodeB := connectPeers(t, a, b)
+
+	status := nodeB.Status()
+	if status.Peer != "a" || nodeA.Status().Peer != "b" {
+		t.Errorf("Expected the peers to exchange names, got %q and %q", status.Peer, nodeA.Status().Peer)
+	}
+
+	b.Start()
+	a.Start()
+
+	waitFor(t, "both balls to be caught by the peer", func() bool { return remoteEvents(b, "catch") == 2 })
+	waitFor(t, "the peer to report the landings", func() bool { return nodeA.Status().InTransit == 0 })
+
+	if a.GetTotalBalls() != 0 || b.GetTotalBalls() != 2 {
+		t.Errorf("Expected both balls to move to the peer, got %d and %d", a.GetTotalBalls(), b.GetTotalBalls())
+	}
+	if passed := remoteEvents(a, "throw"); passed != 2 || nodeA.Status().Passed != 2 || nodeB.Status().Received != 2 {
+		t.Errorf("Expected 2 passes, got %d throws and %+v", passed, nodeA.Status())
+	}
+
+	waitFor(t, "a clock offset estimate", func() bool { return nodeA.Status().RTT > 0 })
+	if offset := nodeA.Status().ClockOffset; math.Abs(offset) > 50 {
+		t.Errorf("Expected no clock offset between processes on one machine, got %.1f ms", offset)
+	}
+}
+
+func TestPeerRejectedPassCountsAsDrop(t *testing.T) {
+	a := newPeerJuggler(t, 1, 1, 1)
+	b := juggler.NewJuggler(0, 0) // never started
+	nodeA, _ := connectPeers(t, a, b)
+
+	a.Start()
+
+	waitFor(t, "the pass to be lost", func() bool { return nodeA.Status().Lost == 1 })
+	if remoteEvents(a, "drop") != 1 {
+		t.Errorf("Expected the rejected pass to be recorded as a drop, got %v", a.GetEvents())
+	}
+}
+
+func TestPeerDisconnectDropsBallsInTransit(t *testing.T) {
+	a := newPeerJuggler(t, 1, 10, 1)
+	b := newPeerJuggler(t, 0, 10, 0)
+	nodeA, nodeB := connectPeers(t, a, b)
+
+	b.Start()
+	a.Start()
+	waitFor(t, "the pass to arrive", func() bool { return nodeB.Status().Received == 1 })
+
+	nodeB.Close()
+
+	waitFor(t, "the ball in transit to be lost", func() bool { return nodeA.Status().Lost == 1 })
+	if nodeA.Status().Connected || remoteEvents(a, "drop") != 1 {
+		t.Errorf("Expected a disconnect and a drop, got %+v", nodeA.Status())
+	}
+}
+
+func TestPeerDisconnectRemovesReceivedBalls(t *testing.T) {
+	a := newPeerJuggler(t, 1, 2, 1)
+	b := newPeerJuggler(t, 0, 2, 0)
+	nodeA, nodeB := connectPeers(t, a, b)
+
+	b.Start()
+	a.Start()
+	waitFor(t, "the pass to arrive", func() bool { return nodeB.Status().Received == 1 })
+
+	nodeA.Close()
+	waitFor(t, "the receiver to notice", func() bool { return !nodeB.Status().Connected })
+
+	if b.GetTotalBalls() != 0 {
+		t.Errorf("Expected the ball of the lost peer to leave the receiver, got %d balls", b.GetTotalBalls())
+	}
+	// Give the removed ball time to have landed
+	time.Sleep(2500 * time.Millisecond)
+	if remoteEvents(b, "catch")+remoteEvents(b, "drop") != 0 {
+		t.Errorf("Expected no landing of a ball the thrower counts as dropped, got %v", b.GetEvents())
+	}
+}
+
+func TestLosePassOfEarlierSession(t *testing.T) {
+	j := newPeerJuggler(t, 1, 1, 0)
+	session := j.GetSession()
+	j.Reset(1, 1)
+
+	j.LosePass(session, 1)
+	if n := remoteEvents(j, "drop"); n != 0 {
+		t.Errorf("Expected a pass of an earlier session to be ignored, got %d drops", n)
+	}
+
+	j.LosePass(j.GetSession(), 1)
+	if n := remoteEvents(j, "drop"); n != 1 {
+		t.Errorf("Expected a pass of the current session to count as a drop, got %d drops", n)
+	}
+}
+
+func TestPeerPassHasPerformer(t *testing.T) {
+	a := newPeerJuggler(t, 2, 1, 1)
+	options := a.GetOptions()
+	options.Passing = "s | s"
+	if err := a.SetOptions(options); err != nil {
+		t.Fatal(err)
+	}
+	a.Reset(2, 1)
+	b := newPeerJuggler(t, 0, 1, 0)
+	connectPeers(t, a, b)
+
+	b.Start()
+	a.Start()
+	waitFor(t, "both passes", func() bool { return remoteEvents(a, "throw") == 2 })
+
+	for _, e := range a.GetEvents() {
+		if e.Remote && e.Type == "throw" && e.Performer == 0 {
+			t.Errorf("Expected the pass to name its thrower, got %+v", e)
+		}
+	}
+}
+
+func TestPeerHandshake(t *testing.T) {
+	nodeA := peer.NewNode(juggler.NewJuggler(0, 0), "a")
+	l, err := nodeA.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	json.NewEncoder(conn).Encode(peer.Message{Type: peer.MsgHello, Version: peer.ProtocolVersion + 1, Name: "future"})
+
+	var reply peer.Message
+	if err := json.NewDecoder(conn).Decode(&reply); err != nil || reply.Type != peer.MsgError {
+		t.Errorf("Expected an unknown version to be refused, got %+v %v", reply, err)
+	}
+
+	b := peer.NewNode(juggler.NewJuggler(0, 0), "b")
+	if err := b.Dial(l.Addr().String()); err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer b.Close()
+	waitFor(t, "the handshake", func() bool { return nodeA.Status().Connected })
+
+	c := peer.NewNode(juggler.NewJuggler(0, 0), "c")
+	if err := c.Dial(l.Addr().String()); err == nil {
+		t.Error("Expected a second peer to be refused")
+	}
+}
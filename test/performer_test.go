@@ -0,0 +1,155 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+// flightTimeVariance returns the variance of the flight times of the throws
+func flightTimeVariance(events []juggler.Event) float64 {
+	var sum, sumSquares, n float64
+	for _, e := range events {
+		if e.Type == "throw" {
+			sum += float64(e.FlightTime)
+			sumSquares += float64(e.FlightTime * e.FlightTime)
+			n++
+		}
+	}
+	mean := sum / n
+	return sumSquares/n - mean*mean
+}
+
+func TestPerformerOffByDefault(t *testing.T) {
+	j := juggler.NewJuggler(3, 1)
+	if _, ok := j.GetPerformer(); ok {
+		t.Error("Expected the performer model to be off by default")
+	}
+
+	opts := juggler.DefaultOptions()
+	opts.Performer.Skill = 1.5
+	if err := j.SetOptions(opts); err == nil {
+		t.Error("Expected a skill above 1 to be rejected")
+	}
+}
+
+func TestPerformerStaminaDecaysAndRecovers(t *testing.T) {
+	opts := juggler.DefaultOptions()
+	opts.Performer = juggler.PerformerOptions{Skill: 1, Fatigue: 0.01}
+	j, clock := simulate(t, opts, 5, 5)
+
+	tired, ok := j.GetPerformer()
+	if !ok {
+		t.Fatal("Expected the performer model to be on")
+	}
+	if tired.Stamina >= 1 || tired.Form >= 1 || tired.MissChance <= 0 {
+		t.Errorf("Expected 5 minutes of throws to tire the performer, got %+v", tired)
+	}
+
+	clock.Advance(time.Minute)
+	rested, _ := j.GetPerformer()
+	if expected := math.Min(1, tired.Stamina+60*juggler.DefaultRecovery); math.Abs(rested.Stamina-expected) > 1e-9 {
+		t.Errorf("Expected stamina to recover to %.3f after a minute of rest, got %.3f", expected, rested.Stamina)
+	}
+}
+
+func TestPerformerSkillAffectsThrowsAndCatches(t *testing.T) {
+	// Full recovery every second keeps the master fresh
+	opts := juggler.DefaultOptions()
+	opts.Performer = juggler.PerformerOptions{Skill: 1, Recovery: 1}
+	master, _ := simulate(t, opts, 3, 5)
+	opts.Performer = juggler.PerformerOptions{Skill: 0.1}
+	novice, _ := simulate(t, opts, 3, 5)
+
+	if m, n := flightTimeVariance(master.GetEvents()), flightTimeVariance(novice.GetEvents()); m >= n {
+		t.Errorf("Expected the master's throws to vary less than the novice's, got %.2f and %.2f", m, n)
+	}
+
+	if drops := juggler.Summarize(master.GetEvents()).Drops; drops != 0 {
+		t.Errorf("Expected a fresh master never to miss, got %d drops", drops)
+	}
+	if drops := juggler.Summarize(novice.GetEvents()).Drops; drops == 0 {
+		t.Error("Expected the novice to miss some catches")
+	}
+}
+
+func TestWebServerPerformer(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	start := func(body string) int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("POST", "/api/v1/start", strings.NewReader(body)))
+		return rr.Code
+	}
+	stats := func() web.StatsResponse {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/stats", nil))
+		var resp web.StatsResponse
+		json.Unmarshal(rr.Body.Bytes(), &resp)
+		return resp
+	}
+
+	if code := start(`{"total_balls": 3, "time_minutes": 1, "performer": {"skill": 2}}`); code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d for an invalid skill, got %d", http.StatusUnprocessableEntity, code)
+	}
+
+	if code := start(`{"total_balls": 3, "time_minutes": 1, "performer": {"skill": 0.8}}`); code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, code)
+	}
+	if p := stats().Performer; p == nil || p.Skill != 0.8 || p.Stamina != 1 {
+		t.Errorf("Expected a fresh performer with skill 0.8, got %+v", p)
+	}
+
+	if code := start(`{"total_balls": 3, "time_minutes": 1, "mode": "restart"}`); code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, code)
+	}
+	if p := stats().Performer; p != nil {
+		t.Errorf("Expected no performer gauges without a skill, got %+v", p)
+	}
+}
+
+func TestPerformerStaminaPerTroupeMember(t *testing.T) {
+	const fatigue = 0.001
+	opts := juggler.DefaultOptions()
+	opts.Passing = "p s | p s"
+	// Next to no recovery, so that stamina only tells how often each threw
+	opts.Performer = juggler.PerformerOptions{Skill: 1, Fatigue: fatigue, Recovery: 1e-12}
+	j, _ := simulate(t, opts, 5, 1)
+
+	throws := make(map[int]int)
+	for _, e := range j.GetEvents() {
+		if e.Type == "throw" {
+			throws[e.Performer]++
+		}
+	}
+	troupe := j.GetTroupe()
+	if len(troupe) != 2 || throws[1] == 0 || throws[2] == 0 {
+		t.Fatalf("Expected both performers to throw, got %v", throws)
+	}
+
+	least := 1.0
+	for _, m := range troupe {
+		if m.State == nil {
+			t.Fatalf("Expected gauges for performer %d", m.Performer)
+		}
+		if want := 1 - float64(throws[m.Performer])*fatigue; math.Abs(m.State.Stamina-want) > 1e-6 {
+			t.Errorf("Expected performer %d to tire only by their own %d throws to %.3f, got %.3f",
+				m.Performer, throws[m.Performer], want, m.State.Stamina)
+		}
+		least = math.Min(least, m.State.Stamina)
+	}
+	if p, _ := j.GetPerformer(); p.Stamina != least {
+		t.Errorf("Expected the gauges of the most tired performer, got stamina %.3f instead of %.3f", p.Stamina, least)
+	}
+}
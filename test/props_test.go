@@ -0,0 +1,162 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/i18n"
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+func TestBallDefValidate(t *testing.T) {
+	valid := []juggler.BallDef{
+		{},
+		{Name: "Red", Color: "#e74c3c", Size: 7, Weight: 120, Type: juggler.PropBall},
+		{Type: juggler.PropClub, Weight: 220},
+		{Type: juggler.PropRing, Size: 32},
+	}
+	for _, d := range valid {
+		if err := d.Validate(); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", d, err)
+		}
+	}
+
+	invalid := []juggler.BallDef{
+		{Name: strings.Repeat("x", 33)},
+		{Color: "red"},
+		{Size: -1},
+		{Weight: 5000},
+		{Type: "diabolo"},
+	}
+	for _, d := range invalid {
+		if err := d.Validate(); err == nil {
+			t.Errorf("Expected %+v to be rejected", d)
+		}
+	}
+}
+
+func TestBallDefsCycleThroughBalls(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetBallDefs([]juggler.BallDef{{Color: "#ff0000"}, {Type: juggler.PropClub}})
+	j.Reset(3, 1)
+	id := j.AddBall()
+
+	_, _, balls := j.GetStats()
+	types := make(map[int]string)
+	for _, ball := range balls {
+		types[ball.ID] = ball.Type + ball.Color
+	}
+	if types[1] != "#ff0000" || types[2] != "club" || types[3] != "#ff0000" || types[id] != "club" {
+		t.Errorf("Expected definitions to repeat every 2 balls, got %v", types)
+	}
+}
+
+func TestBallDefsAffectFlightTimes(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		def      juggler.BallDef
+		min, max int
+	}{
+		{"Ball", juggler.BallDef{}, 5, 10},
+		{"Club", juggler.BallDef{Type: juggler.PropClub}, 6, 11},
+		{"Heavy", juggler.BallDef{Weight: 320}, 5, 8},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			j, _ := simulate(t, juggler.DefaultOptions(), 3, 1, tt.def)
+
+			for _, e := range j.GetEvents() {
+				if e.Type == "throw" && (e.FlightTime < tt.min || e.FlightTime > tt.max) {
+					t.Fatalf("Expected flight times between %d and %d, got %d", tt.min, tt.max, e.FlightTime)
+				}
+			}
+		})
+	}
+}
+
+func TestManualThrowPropRange(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetOptions(juggler.Options{MinFlightTime: 5, MaxFlightTime: 10, ThrowInterval: 500 * time.Millisecond,
+		Manual: true, HoldTime: time.Minute})
+	if err := j.SetBallDefs([]juggler.BallDef{{}, {Type: juggler.PropClub}}); err != nil {
+		t.Fatal(err)
+	}
+	j.Reset(2, 1)
+	j.Start()
+	defer j.Stop()
+
+	// Ball 2 is a club, thrown a second higher than the engine's range
+	_, err := j.Throw(2, 5)
+	if !errors.Is(err, juggler.ErrHeight) {
+		t.Fatalf("Expected ErrHeight below the club's range, got %v", err)
+	}
+	if msg := i18n.Message("en", err); msg != "must be between 6 and 11 seconds" {
+		t.Errorf("Expected the error to report the club's range, got %q", msg)
+	}
+	if _, err := j.Throw(2, 11); err != nil {
+		t.Errorf("Expected a club to fly up to 11 seconds, got %v", err)
+	}
+	if _, err := j.Throw(1, 11); !errors.Is(err, juggler.ErrHeight) {
+		t.Errorf("Expected ErrHeight above a ball's range, got %v", err)
+	}
+}
+
+func TestBallDefsAffectDrops(t *testing.T) {
+	opts := juggler.DefaultOptions()
+	opts.DropChance = 0.2
+	j, _ := simulate(t, opts, 3, 1)
+	summary := juggler.Summarize(j.GetEvents())
+	if summary.Catches == 0 {
+		t.Fatal("Expected standard balls to be caught sometimes")
+	}
+
+	// A heavy, tiny club is dropped every time at the same base chance
+	j, _ = simulate(t, opts, 3, 1, juggler.BallDef{Type: juggler.PropClub, Weight: 1000, Size: 1})
+	summary = juggler.Summarize(j.GetEvents())
+	if summary.Catches != 0 || summary.Drops != 3 {
+		t.Errorf("Expected every club to be dropped, got %d catches and %d drops", summary.Catches, summary.Drops)
+	}
+}
+
+func TestWebServerStartBallDefs(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	req := httptest.NewRequest("POST", "/api/v1/start", strings.NewReader(
+		`{"total_balls": 2, "time_minutes": 1, "balls": [{"type": "ring"}, {"type": "diabolo"}]}`))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	var errResp web.ErrorResponse
+	json.Unmarshal(rr.Body.Bytes(), &errResp)
+	if rr.Code != http.StatusUnprocessableEntity || len(errResp.Error.Details) != 1 || errResp.Error.Details[0].Field != "balls[1]" {
+		t.Fatalf("Expected the second definition to be rejected, got %d %s", rr.Code, rr.Body)
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/start", strings.NewReader(
+		`{"total_balls": 2, "time_minutes": 1, "balls": [{"type": "ring", "color": "#007bff"}]}`))
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/stats", nil))
+	var stats web.StatsResponse
+	json.Unmarshal(rr.Body.Bytes(), &stats)
+	for _, ball := range stats.Balls {
+		if ball.Type != juggler.PropRing || ball.Color != "#007bff" {
+			t.Errorf("Expected every ball to be a blue ring, got %+v", ball)
+		}
+	}
+}
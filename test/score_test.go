@@ -0,0 +1,87 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"juggler/internal/juggler"
+	"juggler/internal/score"
+)
+
+// sessionEvents builds a session where balls are thrown once each and then
+// caught catches times, one event per second
+func sessionEvents(balls, catches int) []juggler.Event {
+	start := time.Date(2025, 7, 8, 12, 0, 0, 0, time.UTC)
+	var events []juggler.Event
+	add := func(id int, typ string) {
+		events = append(events, juggler.Event{Time: start.Add(time.Duration(len(events)) * time.Second), BallID: id, Type: typ})
+	}
+
+	for id := 1; id <= balls; id++ {
+		add(id, "throw")
+	}
+	for i := 0; i < catches; i++ {
+		add(i%balls+1, "catch")
+	}
+	return events
+}
+
+func achievementIDs(s score.Score) map[string]bool {
+	ids := make(map[string]bool)
+	for _, a := range s.Achievements {
+		ids[a.ID] = true
+	}
+	return ids
+}
+
+func TestScoreCatchesAndStreaks(t *testing.T) {
+	s := score.Compute(sessionEvents(3, 12))
+
+	if s.Catches != 12 || s.Streak != 12 || s.LongestStreak != 12 {
+		t.Errorf("Expected 12 catches in a row, got %+v", s)
+	}
+	if expected := 12*score.CatchPoints + score.StreakBonus; s.Points != expected {
+		t.Errorf("Expected %d points, got %d", expected, s.Points)
+	}
+	// 12 catches over the 14 seconds from the first throw to the last catch
+	if expected := 12 / (14.0 / 60); s.CatchesPerMinute != expected {
+		t.Errorf("Expected %.2f catches per minute, got %.2f", expected, s.CatchesPerMinute)
+	}
+
+	ids := achievementIDs(s)
+	if !ids["first_catch"] || !ids["streak_10"] || ids["streak_50"] {
+		t.Errorf("Expected first_catch and streak_10 only, got %v", s.Achievements)
+	}
+}
+
+func TestScoreDropPenalty(t *testing.T) {
+	events := sessionEvents(3, 2)
+	events = append(events, juggler.Event{Time: events[len(events)-1].Time.Add(time.Second), BallID: 1, Type: "drop"})
+
+	s := score.Compute(events)
+	if s.Drops != 1 || s.Streak != 0 || s.LongestStreak != 2 {
+		t.Errorf("Expected the drop to end a streak of 2, got %+v", s)
+	}
+	if s.Points != 0 {
+		t.Errorf("Expected the penalty to floor the score at 0, got %d", s.Points)
+	}
+}
+
+func TestScoreBallBonusAndAchievements(t *testing.T) {
+	s := score.Compute(sessionEvents(5, 60))
+
+	if expected := 60*(score.CatchPoints+2*score.BallBonus) + 6*score.StreakBonus; s.Points != expected {
+		t.Errorf("Expected %d points with the 5 ball bonus, got %d", expected, s.Points)
+	}
+	if ids := achievementIDs(s); !ids["five_ball_minute"] || ids["seven_balls"] {
+		t.Errorf("Expected five_ball_minute to be unlocked, got %v", s.Achievements)
+	}
+
+	if ids := achievementIDs(score.Compute(sessionEvents(7, 1))); !ids["seven_balls"] || ids["five_ball_minute"] {
+		t.Errorf("Expected seven_balls to be unlocked, got %v", ids)
+	}
+
+	if len(score.Compute(nil).Achievements) != 0 {
+		t.Error("Expected no achievements without events")
+	}
+}
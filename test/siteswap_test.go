@@ -0,0 +1,51 @@
+package test
+
+import (
+	"testing"
+
+	"juggler/internal/siteswap"
+)
+
+func TestSiteswapValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		notation    string
+		expectBalls int
+		expectError bool
+	}{
+		{"Cascade", "3", 3, false},
+		{"Shower", "51", 3, false},
+		{"Box-like", "531", 3, false},
+		{"Pattern 441", "441", 3, false},
+		{"Letters", "b97531", 6, false},
+		{"Empty", "", 0, true},
+		{"Bad average", "532", 0, true},
+		{"Collision", "543", 0, true},
+		{"Invalid character", "5#1", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := siteswap.Validate(tt.notation)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q but got none", tt.notation)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if p.Balls() != tt.expectBalls {
+				t.Errorf("Expected %d balls, got %d", tt.expectBalls, p.Balls())
+			}
+
+			if p.Period() != len(tt.notation) {
+				t.Errorf("Expected period %d, got %d", len(tt.notation), p.Period())
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"juggler/internal/web"
+)
+
+func TestHomeFollowsSystemTheme(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	body := getWith(handler, "/", nil).Body.String()
+	if !strings.Contains(body, "<body>") {
+		t.Error("Expected no forced theme without a choice")
+	}
+	if !strings.Contains(body, `id="theme-select"`) {
+		t.Error("Expected a theme switcher")
+	}
+
+	css := getWith(handler, "/static/home.css", nil).Body.String()
+	for _, want := range []string{"color-scheme: light dark", `body[data-theme="dark"]`, "prefers-reduced-motion", "@media (max-width"} {
+		if !strings.Contains(css, want) {
+			t.Errorf("Expected the stylesheet to contain %q", want)
+		}
+	}
+}
+
+func TestHomeChosenTheme(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		cookies []*http.Cookie
+		want    string
+	}{
+		{"cookie", "/", []*http.Cookie{{Name: web.ThemeCookie, Value: "dark"}}, "dark"},
+		{"query", "/?theme=light", []*http.Cookie{{Name: web.ThemeCookie, Value: "dark"}}, "light"},
+		{"unknown", "/", []*http.Cookie{{Name: web.ThemeCookie, Value: "neon"}}, ""},
+	}
+	for _, tt := range tests {
+		body := authRequest(handler, http.MethodGet, tt.path, "", "", tt.cookies, nil).Body.String()
+		if tt.want == "" {
+			if strings.Contains(body, "data-theme=") {
+				t.Errorf("%s: expected no forced theme", tt.name)
+			}
+			continue
+		}
+		if !strings.Contains(body, `<body data-theme="`+tt.want+`">`) {
+			t.Errorf("%s: expected the %s theme", tt.name, tt.want)
+		}
+		if !strings.Contains(body, `value="`+tt.want+`" selected`) {
+			t.Errorf("%s: expected %s selected in the switcher", tt.name, tt.want)
+		}
+	}
+}
+
+func TestHomeAccessibility(t *testing.T) {
+	_, handler, _ := newTestServer(t)
+
+	body := getWith(handler, "/", nil).Body.String()
+	for _, want := range []string{
+		`name="viewport"`,
+		`id="announcer" class="sr-only" aria-live="polite"`,
+		`id="drop-announcer" class="sr-only" role="alert"`,
+		`id="status" role="status"`,
+		`role="progressbar"`,
+		`<th scope="col">`,
+		`class="legend"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page to contain %s", want)
+		}
+	}
+
+	script := getWith(handler, "/static/home.js", nil).Body.String()
+	for _, want := range []string{"function announce", "function setTheme", "aria-label"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected the page script to contain %s", want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"juggler/internal/config"
+	"juggler/internal/juggler"
+	"juggler/internal/web"
+)
+
+func TestSelfSignedCertificate(t *testing.T) {
+	cert, err := web.SelfSignedCertificate("juggler.lan", "192.168.1.20")
+	if err != nil {
+		t.Fatalf("SelfSignedCertificate: %v", err)
+	}
+
+	for _, host := range []string{"localhost", "127.0.0.1", "juggler.lan", "192.168.1.20"} {
+		if err := cert.Leaf.VerifyHostname(host); err != nil {
+			t.Errorf("Expected the certificate to cover %s: %v", host, err)
+		}
+	}
+	if now := time.Now(); now.Before(cert.Leaf.NotBefore) || now.After(cert.Leaf.NotAfter) {
+		t.Errorf("Expected the certificate to be valid now, got %v to %v", cert.Leaf.NotBefore, cert.Leaf.NotAfter)
+	}
+}
+
+func TestWebServerHTTPS(t *testing.T) {
+	cert, err := web.SelfSignedCertificate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	s := web.NewServer(j, 8080)
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+	s.SetTLS(tlsConfig, web.DefaultHSTSMaxAge)
+
+	server := httptest.NewUnstartedServer(s.Handler())
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert.Leaf)
+	hc := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	resp, err := hc.Get(server.URL + "/api/v1/stats")
+	if err != nil {
+		t.Fatalf("GET over HTTPS: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if hsts := resp.Header.Get("Strict-Transport-Security"); hsts != "max-age=31536000" {
+		t.Errorf("Expected HSTS for a year, got %q", hsts)
+	}
+
+	// Browsers ignore HSTS over plain HTTP, so it is not sent there
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/stats", nil))
+	if hsts := rr.Header().Get("Strict-Transport-Security"); hsts != "" {
+		t.Errorf("Expected no HSTS over HTTP, got %q", hsts)
+	}
+}
+
+func TestRedirectHTTPS(t *testing.T) {
+	tests := []struct {
+		method   string
+		url      string
+		port     int
+		status   int
+		location string
+	}{
+		{"GET", "http://example.com:8080/api/v1/stats?x=1", 8443, http.StatusMovedPermanently, "https://example.com:8443/api/v1/stats?x=1"},
+		{"POST", "http://example.com/api/v1/start", 8443, http.StatusPermanentRedirect, "https://example.com:8443/api/v1/start"},
+		{"GET", "http://example.com:80/", 443, http.StatusMovedPermanently, "https://example.com/"},
+	}
+
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		web.RedirectHTTPS(tt.port).ServeHTTP(rr, httptest.NewRequest(tt.method, tt.url, nil))
+		if rr.Code != tt.status || rr.Header().Get("Location") != tt.location {
+			t.Errorf("%s %s: expected %d to %s, got %d to %s", tt.method, tt.url, tt.status, tt.location, rr.Code, rr.Header().Get("Location"))
+		}
+	}
+}
+
+func TestConfigValidateTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	os.WriteFile(certFile, []byte("cert"), 0o600)
+	os.WriteFile(keyFile, []byte("key"), 0o600)
+
+	tests := []struct {
+		name   string
+		modify func(*config.Config)
+		err    string
+	}{
+		{"Certificate and key", func(c *config.Config) { c.TLSCert, c.TLSKey = certFile, keyFile }, ""},
+		{"Self-signed with redirect", func(c *config.Config) { c.TLSSelfSigned, c.HTTPPort = true, 8081 }, ""},
+		{"Certificate without key", func(c *config.Config) { c.TLSCert = certFile }, "together"},
+		{"Missing file", func(c *config.Config) { c.TLSCert, c.TLSKey = certFile, filepath.Join(dir, "nope.pem") }, "nope.pem"},
+		{"Both kinds of certificate", func(c *config.Config) { c.TLSCert, c.TLSKey, c.TLSSelfSigned = certFile, keyFile, true }, "not both"},
+		{"Redirect without TLS", func(c *config.Config) { c.HTTPPort = 8081 }, "needs TLS"},
+		{"Redirect on the web port", func(c *config.Config) { c.TLSSelfSigned, c.HTTPPort = true, 8080 }, "differ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("Unexpected error: %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("Expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"juggler/internal/juggler"
+	"juggler/internal/tui"
+)
+
+func TestDashboardRender(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.Reset(3, 2)
+
+	d := tui.NewDashboard(j, 3, 2)
+	frame := d.Render()
+
+	if !strings.HasPrefix(frame, "\x1b[H") {
+		t.Error("Expected frame to start by moving the cursor home")
+	}
+
+	for _, want := range []string{"Status:   juggling", "In hand:  3", "In air:   0", "Ball   1", "Ball   3", "[q] quit"} {
+		if !strings.Contains(frame, want) {
+			t.Errorf("Expected frame to contain %q", want)
+		}
+	}
+}
+
+func TestDashboardKeys(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.Reset(2, 2)
+
+	d := tui.NewDashboard(j, 2, 2)
+
+	if !d.HandleKey('a') {
+		t.Fatal("Expected dashboard to keep running after adding a ball")
+	}
+	if j.GetTotalBalls() != 3 {
+		t.Errorf("Expected 3 balls after add, got %d", j.GetTotalBalls())
+	}
+
+	d.HandleKey('p')
+	if !j.IsPaused() {
+		t.Error("Expected p to pause the session")
+	}
+	if !strings.Contains(d.Render(), "Status:   paused") {
+		t.Error("Expected paused status in frame")
+	}
+
+	d.HandleKey('p')
+	if j.IsPaused() {
+		t.Error("Expected second p to resume the session")
+	}
+
+	d.HandleKey('x')
+	if !j.IsFinished() {
+		t.Error("Expected x to stop the session")
+	}
+
+	if d.HandleKey('q') {
+		t.Error("Expected q to quit the dashboard")
+	}
+}
@@ -3,14 +3,35 @@ package test
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"juggler/internal/juggler"
 	"juggler/internal/web"
 )
 
+// serverOption sets up a test server or its juggler before the handler is built
+type serverOption func(t *testing.T, s *web.Server, j *juggler.Juggler)
+
+// newTestServer returns a web server over a quiet juggler, stopped when the
+// test ends, with the options applied, along with its handler and juggler
+func newTestServer(t *testing.T, opts ...serverOption) (*web.Server, http.Handler, *juggler.Juggler) {
+	t.Helper()
+
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	t.Cleanup(j.Stop)
+	s := web.NewServer(j, 8080)
+	for _, opt := range opts {
+		opt(t, s, j)
+	}
+	return s, s.Handler(), j
+}
+
 func TestWebServerStats(t *testing.T) {
 	j := juggler.NewJuggler(0, 0)
 	server := web.NewServer(j, 8080)
@@ -79,13 +100,17 @@ func TestWebServerStart(t *testing.T) {
 		t.Errorf("Expected status code %d, got %d", http.StatusOK, status)
 	}
 
-	var response map[string]string
+	var response web.StartResponse
 	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
 		t.Errorf("Failed to parse JSON response: %v", err)
 	}
 
-	if response["status"] != "started" {
-		t.Errorf("Expected status 'started', got %s", response["status"])
+	if response.Status != "started" {
+		t.Errorf("Expected status 'started', got %s", response.Status)
+	}
+
+	if response.Session == nil || response.Session.ID != j.GetSession() {
+		t.Errorf("Expected session %d in the response, got %+v", j.GetSession(), response.Session)
 	}
 
 	if j.GetTotalBalls() != 3 {
@@ -140,10 +165,10 @@ func TestWebServerStartInvalidValues(t *testing.T) {
 		time     int
 		expected int
 	}{
-		{"Zero balls", 0, 2, http.StatusBadRequest},
-		{"Negative balls", -1, 2, http.StatusBadRequest},
-		{"Zero time", 3, 0, http.StatusBadRequest},
-		{"Negative time", 3, -1, http.StatusBadRequest},
+		{"Zero balls", 0, 2, http.StatusUnprocessableEntity},
+		{"Negative balls", -1, 2, http.StatusUnprocessableEntity},
+		{"Zero time", 3, 0, http.StatusUnprocessableEntity},
+		{"Negative time", 3, -1, http.StatusUnprocessableEntity},
 	}
 
 	for _, tt := range tests {
@@ -306,3 +331,517 @@ func containsHTML(s string) bool {
 		bytes.Contains([]byte(s), []byte("<body>")) &&
 		bytes.Contains([]byte(s), []byte("</body>"))
 }
+
+func TestWebServerExperiments(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	server := web.NewServer(j, 8080)
+
+	body := []byte(`{"balls": [1, 2, 3], "drop_chances": [0, 0.05], "minutes": 1, "runs": 3, "seed": 7}`)
+	handler := server.Handler()
+	req := httptest.NewRequest("POST", "/api/v1/experiments", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	var resp web.ExperimentResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+
+	if resp.Configurations != 6 || len(resp.Rows) != 6 {
+		t.Errorf("Expected 6 configurations, got %d with %d rows", resp.Configurations, len(resp.Rows))
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		contentType string
+		contains    string
+	}{
+		{"CSV", resp.Downloads["csv"], "text/csv; charset=utf-8", "balls,min_flight"},
+		{"JSON", resp.Downloads["json"], "application/json", `"rows"`},
+		{"Chart", resp.Downloads["drop_rate.svg"], "image/svg+xml", "<svg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Expected content type %s, got %s", tt.contentType, ct)
+			}
+			if !bytes.Contains(rr.Body.Bytes(), []byte(tt.contains)) {
+				t.Errorf("Expected body to contain %q", tt.contains)
+			}
+		})
+	}
+}
+
+func TestWebServerExperimentErrors(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	server := web.NewServer(j, 8080)
+
+	handler := server.Handler()
+
+	req := httptest.NewRequest("POST", "/api/v1/experiments", bytes.NewBuffer([]byte(`{"balls": []}`)))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d for empty sweep, got %d", http.StatusUnprocessableEntity, rr.Code)
+	}
+
+	limits := []struct {
+		body  string
+		field string
+	}{
+		{`{"minutes": 61}`, "minutes"},
+		{`{"runs": 1001}`, "runs"},
+		{`{"throw_intervals_ms": [1]}`, "throw_intervals_ms[0]"},
+		{`{"balls": [3, 100000]}`, "balls[1]"},
+		{`{"balls": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11], "runs": 1000}`, "sweep"},
+	}
+	for _, tt := range limits {
+		req = httptest.NewRequest("POST", "/api/v1/experiments", strings.NewReader(tt.body))
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusUnprocessableEntity || errorCode(rr) != web.CodeValidationFailed {
+			t.Errorf("Expected 422 validation_failed for %s over the limits, got %d %s", tt.body, rr.Code, rr.Body.String())
+			continue
+		}
+		var resp web.ErrorResponse
+		json.Unmarshal(rr.Body.Bytes(), &resp)
+		if len(resp.Error.Details) != 1 || resp.Error.Details[0].Field != tt.field {
+			t.Errorf("Expected a detail for %s with %s, got %+v", tt.field, tt.body, resp.Error.Details)
+		}
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/experiments/exp-404", nil)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d for unknown experiment, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestWebServerExportCSV(t *testing.T) {
+	j, _ := simulate(t, juggler.DefaultOptions(), 3, 1)
+	server := web.NewServer(j, 8080)
+
+	req := httptest.NewRequest("GET", "/api/export?format=csv", nil)
+	rr := httptest.NewRecorder()
+	server.HandleExport(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	if ct := rr.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("Expected CSV content type, got %s", ct)
+	}
+
+	if cd := rr.Header().Get("Content-Disposition"); !strings.Contains(cd, "juggler-session-20250708-120000-events.csv") {
+		t.Errorf("Expected attachment filename, got %q", cd)
+	}
+
+	lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	if lines[0] != "timestamp,offset,ball_id,event,hand,flight_time" {
+		t.Errorf("Unexpected CSV header %q", lines[0])
+	}
+
+	if len(lines)-1 != len(j.GetEvents()) {
+		t.Errorf("Expected %d event rows, got %d", len(j.GetEvents()), len(lines)-1)
+	}
+
+	if !strings.HasPrefix(lines[1], "2025-07-08T12:00:00.5Z,0.500,1,throw,right,") {
+		t.Errorf("Unexpected first event row %q", lines[1])
+	}
+
+	req = httptest.NewRequest("GET", "/api/export?format=csv&table=balls", nil)
+	rr = httptest.NewRecorder()
+	server.HandleExport(rr, req)
+
+	lines = strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	if lines[0] != "ball_id,throws,catches,drops,air_time" || len(lines) != 4 {
+		t.Errorf("Expected header and 3 ball rows, got %q", rr.Body.String())
+	}
+}
+
+func TestWebServerExportJSON(t *testing.T) {
+	j, _ := simulate(t, juggler.DefaultOptions(), 3, 1)
+	server := web.NewServer(j, 8080)
+
+	req := httptest.NewRequest("GET", "/api/export?format=json", nil)
+	rr := httptest.NewRecorder()
+	server.HandleExport(rr, req)
+
+	var export web.ExportResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &export); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+
+	if export.TotalBalls != 3 || len(export.Balls) != 3 {
+		t.Errorf("Expected 3 balls, got total %d with %d summaries", export.TotalBalls, len(export.Balls))
+	}
+
+	throws := 0
+	for _, b := range export.Balls {
+		throws += b.Throws
+	}
+	if throws == 0 {
+		t.Error("Expected exported balls to have throws")
+	}
+
+	for _, e := range export.Events {
+		if e.Hand != "left" && e.Hand != "right" {
+			t.Errorf("Expected every event to have a hand, got %+v", e)
+			break
+		}
+	}
+
+	req = httptest.NewRequest("GET", "/api/export?format=jsonl", nil)
+	rr = httptest.NewRecorder()
+	server.HandleExport(rr, req)
+
+	lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	if len(lines) != len(export.Events) {
+		t.Errorf("Expected %d JSON lines, got %d", len(export.Events), len(lines))
+	}
+
+	req = httptest.NewRequest("GET", "/api/export?format=xml", nil)
+	rr = httptest.NewRecorder()
+	server.HandleExport(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d for unknown format, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestWebServerExportAfterPause(t *testing.T) {
+	start := time.Date(2025, 7, 8, 12, 0, 0, 0, time.UTC)
+	clock := juggler.NewVirtualClock(start)
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	j.SetClock(clock)
+	opts := juggler.DefaultOptions()
+	opts.Manual = true
+	j.SetOptions(opts)
+	j.Reset(3, 1)
+	j.Start()
+	defer j.Stop()
+
+	if _, err := j.Throw(0, 5); err != nil {
+		t.Fatalf("Throw: %v", err)
+	}
+	clock.Advance(10 * time.Second)
+	j.Pause()
+	clock.Advance(30 * time.Second)
+	j.Resume()
+
+	rr := httptest.NewRecorder()
+	web.NewServer(j, 8080).HandleExport(rr, httptest.NewRequest("GET", "/api/export?format=json", nil))
+	var export web.ExportResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &export); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+
+	if !export.StartTime.Equal(start) {
+		t.Errorf("Expected the session to have started at %v, got %v", start, export.StartTime)
+	}
+	if len(export.Events) == 0 || export.Events[0].Offset != 0 {
+		t.Errorf("Expected the throw before the pause at offset 0, got %+v", export.Events)
+	}
+	if want := `filename="juggler-session-20250708-120000.json"`; !strings.Contains(rr.Header().Get("Content-Disposition"), want) {
+		t.Errorf("Expected the file named by the real start, got %q", rr.Header().Get("Content-Disposition"))
+	}
+}
+
+func TestWebServerErrorEnvelope(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		status int
+		code   string
+	}{
+		{"Invalid body", "POST", "/api/v1/start", "invalid json", http.StatusBadRequest, web.CodeInvalidBody},
+		{"Validation", "POST", "/api/v1/start", `{"total_balls": 0, "time_minutes": 1}`, http.StatusUnprocessableEntity, web.CodeValidationFailed},
+		{"Started", "POST", "/api/v1/start", `{"total_balls": 2, "time_minutes": 1}`, http.StatusOK, ""},
+		{"Conflict", "POST", "/api/v1/start", `{"total_balls": 2, "time_minutes": 1}`, http.StatusConflict, web.CodeConflict},
+		{"Method", "GET", "/api/v1/stop", "", http.StatusMethodNotAllowed, web.CodeMethodNotAllowed},
+		{"Unknown path", "GET", "/api/v1/nothing", "", http.StatusNotFound, web.CodeNotFound},
+		{"Legacy alias", "GET", "/api/stats", "", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Fatalf("Expected status code %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+			if tt.code == "" {
+				return
+			}
+
+			var resp web.ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to parse error envelope: %v", err)
+			}
+			if resp.Error.Code != tt.code || resp.Error.Message == "" {
+				t.Errorf("Expected code %s with a message, got %+v", tt.code, resp.Error)
+			}
+		})
+	}
+}
+
+func TestWebServerStartModes(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	start := func(body string) (int, web.StartResponse) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v1/start", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		var resp web.StartResponse
+		json.Unmarshal(rr.Body.Bytes(), &resp)
+		return rr.Code, resp
+	}
+
+	code, first := start(`{"total_balls": 2, "time_minutes": 1}`)
+	if code != http.StatusOK || first.Status != "started" || first.Session == nil {
+		t.Fatalf("Expected the first start to succeed, got %d %+v", code, first)
+	}
+
+	if code, _ := start(`{"total_balls": 3, "time_minutes": 1, "mode": "reject"}`); code != http.StatusConflict {
+		t.Errorf("Expected status code %d for reject mode, got %d", http.StatusConflict, code)
+	}
+
+	code, queued := start(`{"total_balls": 4, "time_minutes": 1, "mode": "queue"}`)
+	if code != http.StatusAccepted || queued.Status != "queued" || queued.QueuePosition != 1 || queued.Session != nil {
+		t.Errorf("Expected the start to be queued at position 1, got %d %+v", code, queued)
+	}
+
+	code, restarted := start(`{"total_balls": 3, "time_minutes": 1, "mode": "restart"}`)
+	if code != http.StatusOK || restarted.Status != "restarted" || restarted.Session == nil {
+		t.Fatalf("Expected restart to succeed, got %d %+v", code, restarted)
+	}
+	if restarted.Session.ID == first.Session.ID {
+		t.Error("Expected a restart to begin a new session")
+	}
+	if j.GetTotalBalls() != 3 {
+		t.Errorf("Expected 3 balls after restart, got %d", j.GetTotalBalls())
+	}
+
+	if code, _ := start(`{"total_balls": 3, "time_minutes": 1, "mode": "later"}`); code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d for unknown mode, got %d", http.StatusUnprocessableEntity, code)
+	}
+}
+
+func TestWebServerStartQueueRunsAfterSession(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	for _, body := range []string{
+		`{"total_balls": 1, "time_minutes": 1}`,
+		`{"total_balls": 4, "time_minutes": 1, "mode": "queue"}`,
+	} {
+		req := httptest.NewRequest("POST", "/api/v1/start", strings.NewReader(body))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Stop would also clear the queue, so end the session directly
+	j.SetFinished()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for j.GetTotalBalls() != 4 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if j.GetTotalBalls() != 4 || !j.IsRunning() {
+		t.Errorf("Expected the queued session with 4 balls to be running, got %d balls", j.GetTotalBalls())
+	}
+}
+
+func TestWebServerStartRestartDropsQueue(t *testing.T) {
+	_, handler, j := newTestServer(t)
+
+	start := func(body string) (int, web.StartResponse) {
+		t.Helper()
+		rr := authRequest(handler, "POST", "/api/v1/start", "", body, nil, nil)
+		var resp web.StartResponse
+		json.Unmarshal(rr.Body.Bytes(), &resp)
+		return rr.Code, resp
+	}
+
+	start(`{"total_balls": 1, "time_minutes": 1}`)
+	start(`{"total_balls": 4, "time_minutes": 1, "mode": "queue"}`)
+	if code, resp := start(`{"total_balls": 2, "time_minutes": 1, "mode": "restart"}`); code != http.StatusOK || resp.Status != "restarted" {
+		t.Fatalf("Expected the restart to succeed, got %d %+v", code, resp)
+	}
+
+	// The session queued before the restart must not start over the next one
+	if code, resp := start(`{"total_balls": 5, "time_minutes": 1, "mode": "queue"}`); code != http.StatusAccepted || resp.QueuePosition != 1 {
+		t.Fatalf("Expected the restart to have emptied the queue, got %d %+v", code, resp)
+	}
+
+	j.SetFinished()
+	waitFor(t, "the queued session", func() bool { return j.IsRunning() && j.GetTotalBalls() == 5 })}
+
+func TestWebServerStartIdempotencyKey(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/start", strings.NewReader(body))
+		req.Header.Set(web.IdempotencyKeyHeader, "retry-me")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	first := send(`{"total_balls": 2, "time_minutes": 1}`)
+	session := j.GetSession()
+	retried := send(`{"total_balls": 2, "time_minutes": 1}`)
+
+	if first.Code != http.StatusOK || retried.Code != http.StatusOK {
+		t.Fatalf("Expected both attempts to succeed, got %d and %d", first.Code, retried.Code)
+	}
+	if first.Body.String() != retried.Body.String() {
+		t.Errorf("Expected the retry to replay the first response, got %s and %s", first.Body, retried.Body)
+	}
+	if retried.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected the retry to be marked as replayed")
+	}
+	if j.GetSession() != session {
+		t.Error("Expected the retry not to start another session")
+	}
+
+	if rr := send(`{"total_balls": 5, "time_minutes": 1}`); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status code %d when reusing a key, got %d", http.StatusUnprocessableEntity, rr.Code)
+	}
+}
+
+func TestWebServerBalls(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.Reset(3, 1)
+	handler := web.NewServer(j, 8080).Handler()
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		status int
+		total  int
+	}{
+		{"Add", "POST", "/api/v1/balls", http.StatusCreated, 4},
+		{"Remove", "DELETE", "/api/v1/balls/2", http.StatusOK, 3},
+		{"Remove again", "DELETE", "/api/v1/balls/2", http.StatusNotFound, 3},
+		{"Invalid ID", "DELETE", "/api/v1/balls/two", http.StatusBadRequest, 3},
+		{"Wrong method", "GET", "/api/v1/balls/1", http.StatusMethodNotAllowed, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Fatalf("Expected status code %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+			if j.GetTotalBalls() != tt.total {
+				t.Errorf("Expected %d balls, got %d", tt.total, j.GetTotalBalls())
+			}
+		})
+	}
+
+	_, _, balls := j.GetStats()
+	ids := make(map[int]bool)
+	for _, ball := range balls {
+		ids[ball.ID] = true
+	}
+	if !ids[1] || ids[2] || !ids[3] || !ids[4] {
+		t.Errorf("Expected balls 1, 3 and 4 to keep their IDs, got %v", ids)
+	}
+}
+
+func TestWebServerThrow(t *testing.T) {
+	j := juggler.NewJuggler(0, 0)
+	j.SetOutput(io.Discard)
+	handler := web.NewServer(j, 8080).Handler()
+	defer j.Stop()
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := send("POST", "/api/v1/throw", ""); rr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d without a manual session, got %d", http.StatusConflict, rr.Code)
+	}
+
+	if rr := send("POST", "/api/v1/start", `{"total_balls": 3, "time_minutes": 1, "manual": true}`); rr.Code != http.StatusOK {
+		t.Fatalf("Expected manual start to succeed, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := send("POST", "/api/v1/throw", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var resp web.ThrowResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v", err)
+	}
+	if resp.BallID != 1 || resp.Hand != "right" {
+		t.Errorf("Expected ball 1 thrown from the right hand, got %+v", resp)
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"Ball in flight", `{"ball_id": 1}`, http.StatusConflict},
+		{"Unknown ball", `{"ball_id": 9}`, http.StatusNotFound},
+		{"Height out of range", `{"ball_id": 2, "height": 60}`, http.StatusUnprocessableEntity},
+		{"Chosen ball and height", `{"ball_id": 3, "height": 7}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rr := send("POST", "/api/v1/throw", tt.body); rr.Code != tt.status {
+				t.Errorf("Expected status code %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+		})
+	}
+
+	rr = send("GET", "/api/v1/stats", "")
+	var stats web.StatsResponse
+	json.Unmarshal(rr.Body.Bytes(), &stats)
+	if !stats.Manual || stats.InAir != 2 {
+		t.Errorf("Expected a manual session with 2 balls in the air, got manual=%v in_air=%d", stats.Manual, stats.InAir)
+	}
+}